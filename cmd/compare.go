@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/presentation"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var alpha float64
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <runner-a> <runner-b>",
+	Short: "Compare job durations and failure rates of two runners",
+	Long: `Compare the jobs two self-hosted runners ran within the --since window.
+
+For every job both runners ran, the median duration of successful runs and the
+failure rate are shown side by side. Differences are tested for statistical
+significance (Mann-Whitney U test for durations, Fisher's exact test for failure
+rates) and marked when the p-value is below --alpha. p-values are adjusted with
+the Holm-Bonferroni method for testing every job at once, so that differences
+found by chance alone among many jobs are not marked.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCompareCommand,
+}
+
+func init() {
+	compareCmd.Flags().Float64Var(&alpha, "alpha", 0.05, "Significance level below which a difference is marked")
+	rootCmd.AddCommand(compareCmd)
+}
+
+func runCompareCommand(cmd *cobra.Command, args []string) error {
+	if alpha <= 0 || alpha >= 1 {
+		return fmt.Errorf("invalid --alpha value: %g (must be between 0 and 1)", alpha)
+	}
+
+	ctx, repos, outputOptions, finish, err := startSubcommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	runnerLogger := usecase.NewRunnerLogger(repos.jobRepo, repos.runnerRepo)
+	controller := presentation.NewController(runnerLogger, outputOptions)
+	err = controller.RunCompare(ctx, args[0], args[1], usecase.HistoryOptions{Strict: strict}, repos.createdAfter, alpha)
+	return finish(err)
+}
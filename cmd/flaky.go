@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/VeyronSakai/gh-runner-log/internal/presentation"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var flakyCmd = &cobra.Command{
+	Use:   "flaky [<runner-name>...]",
+	Short: "Find flaky jobs and whether their failures concentrate on self-hosted runners",
+	Long: `Find jobs that failed on one attempt of their workflow run and succeeded when
+the run was re-run, within the --since window, and compare how often such flaky
+failures happen on the selected runners and on every other runner in scope.
+
+Retries are often picked up by other runners, so the jobs of every runner in the
+repository or organization are fetched. Runners are selected the same way as for
+viewing job history.`,
+	Args: requireRunnerSelection,
+	RunE: runFlakyCommand,
+}
+
+func init() {
+	addRunnerSelectorFlags(flakyCmd)
+	rootCmd.AddCommand(flakyCmd)
+}
+
+func runFlakyCommand(cmd *cobra.Command, args []string) error {
+	ctx, repos, outputOptions, finish, err := startSubcommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	runnerLogger := usecase.NewRunnerLogger(repos.jobRepo, repos.runnerRepo)
+	controller := presentation.NewController(runnerLogger, outputOptions)
+	err = controller.RunFlaky(ctx, newRunnerSelector(args), usecase.HistoryOptions{Strict: strict}, repos.createdAfter)
+	return finish(err)
+}
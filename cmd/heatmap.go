@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/presentation"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	heatmapMetric string
+	quietHours    int
+)
+
+var heatmapCmd = &cobra.Command{
+	Use:   "heatmap [<runner-name>...]",
+	Short: "Show when self-hosted runners are busy by weekday and hour of day",
+	Long: `Show a heatmap of the load of the selected runners over the --since window,
+bucketed by day of week and hour of day in the local time zone, and the quietest
+run of consecutive hours, for example to schedule maintenance windows.
+
+Each bucket sums every matching hour in the window, so use a window of at least
+a week (e.g. --since 4w) to cover every weekday. Runners are selected the same way
+as for viewing job history.`,
+	Args: requireRunnerSelection,
+	RunE: runHeatmapCommand,
+}
+
+func init() {
+	addRunnerSelectorFlags(heatmapCmd)
+	heatmapCmd.Flags().StringVar(&heatmapMetric, "metric", string(usecase.HeatmapBusy), "What to measure: busy (time spent running jobs) or jobs (jobs started)")
+	heatmapCmd.Flags().IntVar(&quietHours, "window", 4, "Length in hours of the quietest window to look for")
+	rootCmd.AddCommand(heatmapCmd)
+}
+
+func runHeatmapCommand(cmd *cobra.Command, args []string) error {
+	metric, err := usecase.ParseHeatmapMetric(heatmapMetric)
+	if err != nil {
+		return fmt.Errorf("invalid --metric value: %w", err)
+	}
+	if quietHours < 1 || quietHours > 7*24 {
+		return fmt.Errorf("invalid --window value: %d (must be between 1 and 168)", quietHours)
+	}
+
+	ctx, repos, outputOptions, finish, err := startSubcommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	runnerLogger := usecase.NewRunnerLogger(repos.jobRepo, repos.runnerRepo)
+	controller := presentation.NewController(runnerLogger, outputOptions)
+	err = controller.RunHeatmap(ctx, newRunnerSelector(args), usecase.HistoryOptions{Strict: strict}, repos.createdAfter, metric, quietHours)
+	return finish(err)
+}
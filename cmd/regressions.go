@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/presentation"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	regressionFactor float64
+	baselineRuns     int
+	recentSince      string
+)
+
+var regressionsCmd = &cobra.Command{
+	Use:   "regressions [<runner-name>...]",
+	Short: "Find job runs that took much longer than usual on self-hosted runners",
+	Long: `Find successful job runs within the --since window that took longer than
+--factor times their baseline, the median execution time of the previous
+--baseline successful runs of the same job (same repository, workflow and job name).
+Runs without a full baseline are never flagged, and --recent only flags runs of
+the most recent part of the window, using the runs before it as baselines.
+
+Use it to catch build times creeping up on self-hosted runners, for example after
+a lost cache or a filling disk. Runners are selected the same way as for viewing
+job history.`,
+	Args: requireRunnerSelection,
+	RunE: runRegressionsCommand,
+}
+
+func init() {
+	addRunnerSelectorFlags(regressionsCmd)
+	regressionsCmd.Flags().Float64Var(&regressionFactor, "factor", usecase.DefaultRegressionFactor, "Flag runs that took longer than this many times the baseline")
+	regressionsCmd.Flags().IntVar(&baselineRuns, "baseline", usecase.DefaultBaselineRuns, "Number of previous successful runs of a job whose median is the baseline")
+	regressionsCmd.Flags().StringVar(&recentSince, "recent", "", "Only flag runs started since this time (e.g., '24h', '2d', or RFC3339 format); earlier runs in the --since window only serve as baselines")
+	rootCmd.AddCommand(regressionsCmd)
+}
+
+func runRegressionsCommand(cmd *cobra.Command, args []string) error {
+	if regressionFactor <= 1 {
+		return fmt.Errorf("invalid --factor value: %g (must be greater than 1)", regressionFactor)
+	}
+	if baselineRuns < usecase.MinBaselineRuns {
+		return fmt.Errorf("invalid --baseline value: %d (must be at least %d)", baselineRuns, usecase.MinBaselineRuns)
+	}
+	var recent time.Time
+	if recentSince != "" {
+		var err error
+		recent, err = usecase.ParseSince(recentSince)
+		if err != nil {
+			return fmt.Errorf("invalid --recent value: %w", err)
+		}
+	}
+
+	ctx, repos, outputOptions, finish, err := startSubcommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	runnerLogger := usecase.NewRunnerLogger(repos.jobRepo, repos.runnerRepo)
+	controller := presentation.NewController(runnerLogger, outputOptions)
+	err = controller.RunRegressions(ctx, newRunnerSelector(args), usecase.HistoryOptions{Strict: strict}, repos.createdAfter, usecase.RegressionOptions{
+		BaselineRuns: baselineRuns,
+		Factor:       regressionFactor,
+		Recent:       recent,
+	})
+	return finish(err)
+}
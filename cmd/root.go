@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"time"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
@@ -16,20 +19,54 @@ import (
 )
 
 var (
-	org       string
-	repo      string
-	maxCount  int
-	debugFile string
-	since     string
+	org         string
+	repo        string
+	maxCount    int
+	debugFile   string
+	since       string
+	jsonOut     bool
+	format      string
+	columns     []string
+	jqExpr      string
+	tmpl        string
+	concurrency int
+	verbose     bool
+	strict      bool
+	timeout     time.Duration
+	matchName   bool
+	regex       bool
+	group       string
+	labels      []string
+	watch       time.Duration
 )
 
+// Refresh intervals of --watch; a short interval quickly uses up the API rate limit
+const (
+	defaultWatchInterval = 30 * time.Second
+	minWatchInterval     = 5 * time.Second
+)
+
+// repositories bundles the data sources shared by every command
+type repositories struct {
+	jobRepo    repository.JobRepository
+	runnerRepo repository.RunnerRepository
+	// client is the GitHub client the repositories share, or nil when serving debug data
+	client *github.Client
+	// createdAfter is the start of the --since window
+	createdAfter time.Time
+}
+
 var rootCmd = &cobra.Command{
-	Use:   "gh-runner-log <runner-name>",
+	Use:   "gh-runner-log [<runner-name>...]",
 	Short: "View job execution history for GitHub Actions self-hosted runners",
 	Long: `GitHub Actions Runner Log is a CLI tool that displays the job execution 
-history for a specific self-hosted runner. It shows completed and in-progress 
-jobs with details like workflow name, status, duration, and more.`,
-	Args: cobra.ExactArgs(1),
+history for one or more self-hosted runners. It shows completed and in-progress 
+jobs with details like workflow name, status, duration, and more.
+
+Runner names may be globs such as 'linux-*', --group selects every runner
+in an organization runner group, and --label selects runners by their labels.
+The history of all selected runners is merged.`,
+	Args: requireRunnerSelection,
 	RunE: runCommand,
 }
 
@@ -41,63 +78,245 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.Flags().StringVar(&org, "org", "", "Fetch runner logs for an organization")
-	rootCmd.Flags().StringVar(&repo, "repo", "", "Fetch runner logs for a specific repository (owner/repo)")
+	// Scope, time window and output flags are shared with subcommands
+	rootCmd.PersistentFlags().StringVar(&org, "org", "", "Fetch runner logs for an organization")
+	rootCmd.PersistentFlags().StringVar(&repo, "repo", "", "Fetch runner logs for a specific repository (owner/repo)")
+	rootCmd.PersistentFlags().StringVar(&debugFile, "debug", "", "Path to debug JSON file (bypasses GitHub API)")
+	rootCmd.PersistentFlags().StringVar(&since, "since", "24h", "Show jobs created since this time (e.g., '24h', '2d', '1w', or RFC3339 format)")
+	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "Write the result as JSON to stdout instead of launching the interactive UI")
+	rootCmd.PersistentFlags().StringVarP(&jqExpr, "jq", "q", "", "Filter JSON output using a jq expression")
+	rootCmd.PersistentFlags().StringVarP(&tmpl, "template", "t", "", "Format JSON output using a Go template; see \"gh help formatting\"")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", 10, "Maximum number of workflow runs whose jobs are fetched concurrently")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Print API usage and remaining rate limit quota to stderr")
+	rootCmd.PersistentFlags().BoolVar(&strict, "strict", false, "Fail if the jobs of any workflow run cannot be fetched instead of showing partial results")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Abort fetching job history after this duration (e.g., '30s', '5m'); 0 means no timeout")
+	rootCmd.MarkFlagsMutuallyExclusive("jq", "template")
+
 	rootCmd.Flags().IntVarP(&maxCount, "max-count", "n", 20, "Maximum number of jobs to display")
-	rootCmd.Flags().StringVar(&debugFile, "debug", "", "Path to debug JSON file (bypasses GitHub API)")
-	rootCmd.Flags().StringVar(&since, "since", "24h", "Show jobs created since this time (e.g., '24h', '2d', '1w', or RFC3339 format)")
+	rootCmd.Flags().StringVar(&format, "format", "", "Write job history to stdout in the given format: csv or tsv")
+	rootCmd.Flags().StringSliceVar(&columns, "columns", nil, "Comma-separated columns for --format (workflow, job, attempt, status, conclusion, queued, queued_seconds, started_at, duration, duration_seconds, completed_at, job_id, run_id, repository, runner, url)")
+	rootCmd.Flags().DurationVar(&watch, "watch", 0, "Keep the interactive UI open and refresh the job history at this interval (e.g., '--watch', '--watch=10s')")
+	// A bare --watch refreshes at the default interval
+	rootCmd.Flags().Lookup("watch").NoOptDefVal = defaultWatchInterval.String()
+	rootCmd.MarkFlagsMutuallyExclusive("json", "format")
+	rootCmd.MarkFlagsMutuallyExclusive("format", "jq")
+	rootCmd.MarkFlagsMutuallyExclusive("format", "template")
+	addRunnerSelectorFlags(rootCmd)
 }
 
-func runCommand(_ *cobra.Command, args []string) error {
-	ctx := context.Background()
-	runnerName := args[0]
+func runCommand(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("watch") {
+		if watch < minWatchInterval {
+			return fmt.Errorf("invalid --watch value: %s (must be at least %s)", watch, minWatchInterval)
+		}
+		if timeout > 0 {
+			return fmt.Errorf("--watch cannot be combined with --timeout")
+		}
+		if err := checkBareWatchArgs(os.Args[1:], args); err != nil {
+			return err
+		}
+	}
+
+	// Arguments and flags are valid at this point; don't print usage for runtime errors
+	cmd.SilenceUsage = true
 
-	owner, repoName, orgName, err := determineScope(debugFile != "", org, repo)
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	outputOptions, err := resolveOutputOptions(jsonOut, format, columns, jqExpr, tmpl)
 	if err != nil {
 		return err
 	}
-
-	// Parse since parameter
-	createdAfter, err := usecase.ParseSince(since)
-	if err != nil {
-		return fmt.Errorf("invalid --since value: %w", err)
+	if watch > 0 && outputOptions.Format != presentation.FormatTUI {
+		return fmt.Errorf("--watch can only be used with the interactive UI")
 	}
+	outputOptions.Watch = watch
 
-	jobRepo, runnerRepo, err := resolveRepositories(debugFile, owner, repoName, orgName, createdAfter)
+	repos, err := loadRepositories()
 	if err != nil {
 		return err
 	}
+	if verbose && repos.client != nil {
+		defer printClientStats(os.Stderr, repos.client)
+	}
 
 	// Create use case
-	runnerLogger := usecase.NewRunnerLogger(jobRepo, runnerRepo)
+	runnerLogger := usecase.NewRunnerLogger(repos.jobRepo, repos.runnerRepo)
 
 	// Create and run controller
-	controller := presentation.NewController(runnerLogger)
-	return controller.Run(ctx, runnerName, maxCount)
+	controller := presentation.NewController(runnerLogger, outputOptions)
+	err = controller.Run(ctx, newRunnerSelector(args), usecase.HistoryOptions{
+		Limit:  maxCount,
+		Strict: strict,
+	}, repos.createdAfter)
+	return wrapTimeout(err)
+}
+
+// checkBareWatchArgs rejects runner names that look like a refresh interval after a bare --watch
+// A bare --watch takes no value, so "--watch 10s" leaves the interval as a runner name.
+// rawArgs are the command line arguments before parsing, args the runner names parsed from them.
+func checkBareWatchArgs(rawArgs, args []string) error {
+	if !hasBareWatch(rawArgs) {
+		return nil
+	}
+	for _, arg := range args {
+		if _, err := time.ParseDuration(arg); err == nil {
+			return fmt.Errorf("%q is not a runner name; use --watch=%s to set the refresh interval", arg, arg)
+		}
+	}
+	return nil
+}
+
+// hasBareWatch reports whether --watch is given without "=" before any "--" terminator
+func hasBareWatch(rawArgs []string) bool {
+	for _, arg := range rawArgs {
+		switch arg {
+		case "--":
+			return false
+		case "--watch":
+			return true
+		}
+	}
+	return false
+}
+
+// startSubcommand does the setup the report subcommands share once their arguments and flags
+// are valid: it creates the context for API requests, resolves the --json, --jq and --template
+// output options and loads the repositories
+// The subcommand must pass the error of its controller through finish, which explains timeouts,
+// prints API usage with --verbose and cancels the context.
+func startSubcommand(cmd *cobra.Command) (ctx context.Context, repos *repositories, outputOptions presentation.Options, finish func(error) error, err error) {
+	// Arguments and flags are valid at this point; don't print usage for runtime errors
+	cmd.SilenceUsage = true
+
+	ctx, cancel := commandContext()
+	outputOptions, err = resolveOutputOptions(jsonOut, "", nil, jqExpr, tmpl)
+	if err != nil {
+		cancel()
+		return nil, nil, presentation.Options{}, nil, err
+	}
+
+	repos, err = loadRepositories()
+	if err != nil {
+		cancel()
+		return nil, nil, presentation.Options{}, nil, err
+	}
+
+	finish = func(err error) error {
+		defer cancel()
+		if verbose && repos.client != nil {
+			defer printClientStats(os.Stderr, repos.client)
+		}
+		return wrapTimeout(err)
+	}
+	return ctx, repos, outputOptions, finish, nil
+}
+
+// commandContext returns the context for API requests
+// Ctrl+C in non-interactive modes and --timeout cancel all in-flight API requests.
+func commandContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// wrapTimeout explains errors caused by --timeout expiring
+func wrapTimeout(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+	return err
+}
+
+// loadRepositories validates the scope, --since and --concurrency flags and creates the repositories
+func loadRepositories() (*repositories, error) {
+	owner, repoName, orgName, err := determineScope(debugFile != "", org, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse since parameter
+	createdAfter, err := usecase.ParseSince(since)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --since value: %w", err)
+	}
+
+	if concurrency < 1 {
+		return nil, fmt.Errorf("invalid --concurrency value: %d (must be at least 1)", concurrency)
+	}
+
+	jobRepo, runnerRepo, client, err := resolveRepositories(debugFile, owner, repoName, orgName, createdAfter)
+	if err != nil {
+		return nil, err
+	}
+	return &repositories{
+		jobRepo:      jobRepo,
+		runnerRepo:   runnerRepo,
+		client:       client,
+		createdAfter: createdAfter,
+	}, nil
 }
 
-func resolveRepositories(debugPath, owner, repo, org string, createdAfter time.Time) (repository.JobRepository, repository.RunnerRepository, error) {
+// resolveRepositories returns the repositories to read from, along with the GitHub client
+// they share (nil when serving debug data)
+func resolveRepositories(debugPath, owner, repo, org string, createdAfter time.Time) (repository.JobRepository, repository.RunnerRepository, *github.Client, error) {
 	if debugPath != "" {
 		jobRepo, runnerRepo, err := debuginfra.LoadRepositories(debugPath, owner, repo, org, createdAfter)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to load debug data: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to load debug data: %w", err)
 		}
-		return jobRepo, runnerRepo, nil
+		return jobRepo, runnerRepo, nil, nil
+	}
+
+	client, err := github.NewClient()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create GitHub client: %w", err)
 	}
 
 	basePath := github.GetActionsBasePath(owner, repo, org)
+	runnerRepo := github.NewRunnerRepository(client, basePath)
+	jobRepo := github.NewJobRepository(client, basePath, createdAfter, concurrency)
 
-	runnerRepo, err := github.NewRunnerRepository(basePath)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create GitHub client: %w", err)
+	return jobRepo, runnerRepo, client, nil
+}
+
+// printClientStats reports API usage and the remaining rate limit quota
+func printClientStats(w io.Writer, client *github.Client) {
+	stats := client.Stats()
+	fmt.Fprintf(w, "API requests: %d (%d retried after rate limiting)\n", stats.Requests, stats.Retries)
+	if stats.RateLimit != nil {
+		fmt.Fprintf(w, "API rate limit: %d/%d remaining, resets at %s\n",
+			stats.RateLimit.Remaining,
+			stats.RateLimit.Limit,
+			stats.RateLimit.Reset.Local().Format("15:04:05"),
+		)
 	}
+}
 
-	jobRepo, err := github.NewJobRepository(basePath, createdAfter)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create GitHub job client: %w", err)
+func resolveOutputOptions(jsonFlag bool, formatFlag string, columnsFlag []string, jqFlag, templateFlag string) (presentation.Options, error) {
+	opts := presentation.Options{Columns: columnsFlag, JQ: jqFlag, Template: templateFlag}
+
+	switch {
+	case jsonFlag, jqFlag != "", templateFlag != "":
+		opts.Format = presentation.FormatJSON
+	case formatFlag == presentation.FormatCSV, formatFlag == presentation.FormatTSV:
+		opts.Format = formatFlag
+	case formatFlag != "":
+		return opts, fmt.Errorf("invalid --format value: %s (expected csv or tsv)", formatFlag)
+	}
+
+	if len(columnsFlag) > 0 && opts.Format != presentation.FormatCSV && opts.Format != presentation.FormatTSV {
+		return opts, fmt.Errorf("--columns can only be used with --format csv or --format tsv")
 	}
 
-	return jobRepo, runnerRepo, nil
+	return opts, nil
 }
 
 func determineScope(debugEnabled bool, orgFlag, repoFlag string) (string, string, string, error) {
@@ -0,0 +1,27 @@
+package cmd
+
+import "testing"
+
+func TestCheckBareWatchArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawArgs []string
+		args    []string
+		wantErr bool
+	}{
+		{name: "space-separated interval", rawArgs: []string{"runner-a", "--watch", "10s"}, args: []string{"runner-a", "10s"}, wantErr: true},
+		{name: "explicit interval with duration-like name", rawArgs: []string{"--watch=30s", "10m"}, args: []string{"10m"}},
+		{name: "explicit default interval with duration-like name", rawArgs: []string{"--watch=30s", "--", "10m"}, args: []string{"10m"}},
+		{name: "bare watch", rawArgs: []string{"runner-a", "--watch"}, args: []string{"runner-a"}},
+		{name: "bare watch after terminator", rawArgs: []string{"--watch=10s", "--", "--watch", "1m"}, args: []string{"--watch", "1m"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBareWatchArgs(tt.rawArgs, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkBareWatchArgs(%q, %q) error = %v, wantErr %v", tt.rawArgs, tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/VeyronSakai/gh-runner-log/internal/presentation"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var runnerLabels []string
+
+var runnersCmd = &cobra.Command{
+	Use:     "runners",
+	Aliases: []string{"ls"},
+	Short:   "List every runner with a summary of its recent jobs",
+	Long: `List every self-hosted runner in the repository or organization with its
+status, busy flag, OS and labels, along with the last job it picked up, the
+number of jobs it ran and its success rate within the --since window.`,
+	Args: cobra.NoArgs,
+	RunE: runRunnersCommand,
+}
+
+func init() {
+	runnersCmd.Flags().StringArrayVar(&runnerLabels, "label", nil, "Only list runners with this label; repeat to require several labels")
+	rootCmd.AddCommand(runnersCmd)
+}
+
+func runRunnersCommand(cmd *cobra.Command, _ []string) error {
+	ctx, repos, outputOptions, finish, err := startSubcommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	runnerLogger := usecase.NewRunnerLogger(repos.jobRepo, repos.runnerRepo)
+	controller := presentation.NewController(runnerLogger, outputOptions)
+	err = controller.RunFleet(ctx, usecase.FleetOptions{
+		Labels: runnerLabels,
+		Strict: strict,
+	}, repos.createdAfter)
+	return finish(err)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// addRunnerSelectorFlags registers the flags that choose runners alongside <runner-name> arguments
+func addRunnerSelectorFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&matchName, "match-name", false, "Match jobs by the runner name recorded on each job instead of looking up the runner, for ephemeral or deleted runners that are no longer registered")
+	cmd.Flags().BoolVar(&regex, "regex", false, "Treat <runner-name> as a regular expression (implies --match-name)")
+	cmd.Flags().StringVar(&group, "group", "", "Include every runner in this organization runner group (requires --org)")
+	cmd.Flags().StringArrayVar(&labels, "label", nil, "Only include runners with this label; repeat to require several labels (e.g., --label linux --label arm64)")
+	cmd.MarkFlagsMutuallyExclusive("group", "match-name")
+	cmd.MarkFlagsMutuallyExclusive("group", "regex")
+	cmd.MarkFlagsMutuallyExclusive("label", "match-name")
+	cmd.MarkFlagsMutuallyExclusive("label", "regex")
+}
+
+// requireRunnerSelection checks that runners are selected by name, --group or --label
+func requireRunnerSelection(_ *cobra.Command, args []string) error {
+	if len(args) == 0 && group == "" && len(labels) == 0 {
+		return fmt.Errorf("requires at least one runner name, --group or --label")
+	}
+	return nil
+}
+
+// newRunnerSelector builds the runner selector from the arguments and selector flags
+func newRunnerSelector(args []string) usecase.RunnerSelector {
+	return usecase.RunnerSelector{
+		Names:           args,
+		Group:           group,
+		Labels:          labels,
+		MatchJobsByName: matchName || regex,
+		Regex:           regex,
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/VeyronSakai/gh-runner-log/internal/presentation"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [<runner-name>...]",
+	Short: "Report job statistics for self-hosted runners",
+	Long: `Report statistics over every job the selected runners picked up within the
+--since window: job counts by conclusion, success and failure rates, p50/p90/p99
+execution time and throughput in jobs per hour and per day.
+
+Runners are selected the same way as for viewing job history.`,
+	Args: requireRunnerSelection,
+	RunE: runStatsCommand,
+}
+
+func init() {
+	addRunnerSelectorFlags(statsCmd)
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStatsCommand(cmd *cobra.Command, args []string) error {
+	ctx, repos, outputOptions, finish, err := startSubcommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	runnerLogger := usecase.NewRunnerLogger(repos.jobRepo, repos.runnerRepo)
+	controller := presentation.NewController(runnerLogger, outputOptions)
+	err = controller.RunStats(ctx, newRunnerSelector(args), usecase.HistoryOptions{Strict: strict}, repos.createdAfter)
+	return finish(err)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/presentation"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var maxGaps int
+
+var utilizationCmd = &cobra.Command{
+	Use:   "utilization [<runner-name>...]",
+	Short: "Report how busy self-hosted runners were and their longest idle periods",
+	Long: `Report the time the selected runners spent running jobs compared to the
+wall-clock time of the --since window, a per-day utilization breakdown, and the
+largest idle gaps between consecutive jobs on the same runner.
+
+Runners are selected the same way as for viewing job history.`,
+	Args: requireRunnerSelection,
+	RunE: runUtilizationCommand,
+}
+
+func init() {
+	addRunnerSelectorFlags(utilizationCmd)
+	utilizationCmd.Flags().IntVar(&maxGaps, "gaps", 5, "Number of largest idle gaps to list")
+	rootCmd.AddCommand(utilizationCmd)
+}
+
+func runUtilizationCommand(cmd *cobra.Command, args []string) error {
+	if maxGaps < 0 {
+		return fmt.Errorf("invalid --gaps value: %d (must not be negative)", maxGaps)
+	}
+
+	ctx, repos, outputOptions, finish, err := startSubcommand(cmd)
+	if err != nil {
+		return err
+	}
+
+	runnerLogger := usecase.NewRunnerLogger(repos.jobRepo, repos.runnerRepo)
+	controller := presentation.NewController(runnerLogger, outputOptions)
+	err = controller.RunUtilization(ctx, newRunnerSelector(args), usecase.HistoryOptions{Strict: strict}, repos.createdAfter, maxGaps)
+	return finish(err)
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"regexp"
+	"sort"
+	"time"
+)
 
 // Job status constants
 const (
@@ -9,6 +13,15 @@ const (
 	StatusQueued     = "queued"
 )
 
+// Job conclusion constants
+const (
+	ConclusionSuccess   = "success"
+	ConclusionFailure   = "failure"
+	ConclusionTimedOut  = "timed_out"
+	ConclusionSkipped   = "skipped"
+	ConclusionCancelled = "cancelled"
+)
+
 // Job represents a GitHub Actions workflow job
 type Job struct {
 	ID           int64
@@ -19,11 +32,14 @@ type Job struct {
 	Conclusion   string
 	RunnerID     *int64
 	RunnerName   *string
+	CreatedAt    *time.Time
 	StartedAt    *time.Time
 	CompletedAt  *time.Time
 	WorkflowName string
 	Repository   string
 	HtmlUrl      string
+	// Steps are the steps of the job in execution order; empty when the API did not report them
+	Steps []Step
 }
 
 // IsCompleted returns true if the job has finished execution
@@ -31,11 +47,33 @@ func (j *Job) IsCompleted() bool {
 	return j.Status == StatusCompleted
 }
 
+// IsSucceeded returns true if the job completed successfully
+func (j *Job) IsSucceeded() bool {
+	return j.IsCompleted() && j.Conclusion == ConclusionSuccess
+}
+
+// IsConcluded returns true if the job completed without being skipped
+// Only concluded jobs count towards success and failure rates.
+func (j *Job) IsConcluded() bool {
+	return j.IsCompleted() && j.Conclusion != ConclusionSkipped
+}
+
+// IsFailed returns true if the job completed with a failure or timed out
+func (j *Job) IsFailed() bool {
+	return j.IsCompleted() && (j.Conclusion == ConclusionFailure || j.Conclusion == ConclusionTimedOut)
+}
+
 // IsAssignedToRunner returns true if the job is assigned to a specific runner
 func (j *Job) IsAssignedToRunner(runnerID int64) bool {
 	return j.RunnerID != nil && *j.RunnerID == runnerID
 }
 
+// IsRunByRunnerMatching returns true if the job was picked up by a runner whose name matches pattern
+// Unlike IsAssignedToRunner, this works for ephemeral runners that are no longer registered.
+func (j *Job) IsRunByRunnerMatching(pattern *regexp.Regexp) bool {
+	return j.RunnerName != nil && *j.RunnerName != "" && pattern.MatchString(*j.RunnerName)
+}
+
 // GetExecutionDuration returns the duration from start to completion
 func (j *Job) GetExecutionDuration() time.Duration {
 	if j.StartedAt == nil || j.CompletedAt == nil {
@@ -43,3 +81,25 @@ func (j *Job) GetExecutionDuration() time.Duration {
 	}
 	return j.CompletedAt.Sub(*j.StartedAt)
 }
+
+// GetQueueDuration returns how long the job waited for a runner, from creation to start
+func (j *Job) GetQueueDuration() time.Duration {
+	if j.CreatedAt == nil || j.StartedAt == nil {
+		return 0
+	}
+	return j.StartedAt.Sub(*j.CreatedAt)
+}
+
+// SortByStartedAtDesc sorts jobs by start time, most recent first
+// Jobs that have not started yet are placed last, keeping their relative order.
+func SortByStartedAtDesc(jobs []*Job) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].StartedAt == nil {
+			return false
+		}
+		if jobs[j].StartedAt == nil {
+			return true
+		}
+		return jobs[i].StartedAt.After(*jobs[j].StartedAt)
+	})
+}
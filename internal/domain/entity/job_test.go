@@ -38,6 +38,48 @@ func TestJob_IsCompleted(t *testing.T) {
 	}
 }
 
+func TestJob_IsConcluded(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		conclusion string
+		expected   bool
+	}{
+		{
+			name:       "successful job",
+			status:     "completed",
+			conclusion: "success",
+			expected:   true,
+		},
+		{
+			name:       "failed job",
+			status:     "completed",
+			conclusion: "failure",
+			expected:   true,
+		},
+		{
+			name:       "skipped job",
+			status:     "completed",
+			conclusion: "skipped",
+			expected:   false,
+		},
+		{
+			name:     "in_progress job",
+			status:   "in_progress",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &Job{Status: tt.status, Conclusion: tt.conclusion}
+			if got := job.IsConcluded(); got != tt.expected {
+				t.Errorf("IsConcluded() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestJob_IsAssignedToRunner(t *testing.T) {
 	runnerID := int64(123)
 
@@ -128,3 +170,59 @@ func TestJob_GetExecutionDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_GetQueueDuration(t *testing.T) {
+	createdTime := time.Date(2025, 11, 15, 9, 58, 0, 0, time.UTC)
+	startTime := time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		job      *Job
+		expected time.Duration
+	}{
+		{
+			name:     "started job",
+			job:      &Job{CreatedAt: &createdTime, StartedAt: &startTime},
+			expected: 2 * time.Minute,
+		},
+		{
+			name:     "queued job",
+			job:      &Job{CreatedAt: &createdTime},
+			expected: 0,
+		},
+		{
+			name:     "job without creation time",
+			job:      &Job{StartedAt: &startTime},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.job.GetQueueDuration(); got != tt.expected {
+				t.Errorf("GetQueueDuration() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSortByStartedAtDesc(t *testing.T) {
+	early := time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC)
+	late := early.Add(time.Hour)
+
+	jobs := []*Job{
+		{ID: 1, StartedAt: nil},
+		{ID: 2, StartedAt: &early},
+		{ID: 3, StartedAt: &late},
+		{ID: 4, StartedAt: nil},
+	}
+
+	SortByStartedAtDesc(jobs)
+
+	expected := []int64{3, 2, 1, 4}
+	for i, id := range expected {
+		if jobs[i].ID != id {
+			t.Errorf("at index %d: expected job %d, got %d", i, id, jobs[i].ID)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package entity
 
+import "strings"
+
 // Runner represents a GitHub Actions self-hosted runner
 type Runner struct {
 	ID     int64
@@ -7,4 +9,24 @@ type Runner struct {
 	Labels []string
 	OS     string
 	Status string
+	// Busy reports whether the runner is currently executing a job
+	Busy bool
+}
+
+// HasLabels reports whether the runner has every given label
+// Labels are compared case-insensitively, as GitHub does when matching runs-on
+func (r *Runner) HasLabels(labels ...string) bool {
+	for _, want := range labels {
+		found := false
+		for _, label := range r.Labels {
+			if strings.EqualFold(label, want) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,26 @@
+package entity
+
+import "testing"
+
+func TestRunner_HasLabels(t *testing.T) {
+	runner := &Runner{Labels: []string{"self-hosted", "Linux", "ARM64"}}
+
+	tests := []struct {
+		name     string
+		labels   []string
+		expected bool
+	}{
+		{name: "no labels", labels: nil, expected: true},
+		{name: "single label", labels: []string{"self-hosted"}, expected: true},
+		{name: "all labels case-insensitively", labels: []string{"linux", "arm64"}, expected: true},
+		{name: "missing label", labels: []string{"linux", "gpu"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runner.HasLabels(tt.labels...); got != tt.expected {
+				t.Errorf("HasLabels(%v) = %v, expected %v", tt.labels, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Step represents a step of a GitHub Actions job
+type Step struct {
+	// Number is the 1-based position of the step in the job
+	Number      int
+	Name        string
+	Status      string
+	Conclusion  string
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// IsCompleted returns true if the step has finished execution
+func (s *Step) IsCompleted() bool {
+	return s.Status == StatusCompleted
+}
+
+// GetExecutionDuration returns the duration from start to completion
+func (s *Step) GetExecutionDuration() time.Duration {
+	if s.StartedAt == nil || s.CompletedAt == nil {
+		return 0
+	}
+	return s.CompletedAt.Sub(*s.StartedAt)
+}
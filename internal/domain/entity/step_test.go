@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStep_GetExecutionDuration(t *testing.T) {
+	startTime := time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC)
+	completedTime := time.Date(2025, 11, 15, 10, 1, 15, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		step     *Step
+		expected time.Duration
+	}{
+		{
+			name:     "completed step",
+			step:     &Step{Status: StatusCompleted, StartedAt: &startTime, CompletedAt: &completedTime},
+			expected: time.Minute + 15*time.Second,
+		},
+		{
+			name:     "running step",
+			step:     &Step{Status: StatusInProgress, StartedAt: &startTime},
+			expected: 0,
+		},
+		{
+			name:     "pending step",
+			step:     &Step{Status: StatusQueued},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.step.GetExecutionDuration(); got != tt.expected {
+				t.Errorf("GetExecutionDuration() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
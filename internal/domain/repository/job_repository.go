@@ -2,13 +2,65 @@ package repository
 
 import (
 	"context"
+	"regexp"
+	"slices"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
 )
 
+// JobQuery describes which jobs FetchJobHistory should return
+type JobQuery struct {
+	// RunnerIDs restricts the result to jobs assigned to any of these runners when non-empty
+	RunnerIDs []int64
+	// RunnerNamePattern restricts the result to jobs whose runner name matches when set
+	RunnerNamePattern *regexp.Regexp
+	// Limit is the maximum number of jobs to return; 0 means no limit
+	Limit int
+	// Strict makes FetchJobHistory fail on the first run whose jobs cannot be fetched
+	// instead of reporting it in JobHistoryResult.Failures
+	Strict bool
+}
+
+// Matches reports whether the job satisfies the runner filters of the query
+func (q JobQuery) Matches(job *entity.Job) bool {
+	if len(q.RunnerIDs) > 0 && !slices.ContainsFunc(q.RunnerIDs, job.IsAssignedToRunner) {
+		return false
+	}
+	if q.RunnerNamePattern != nil && !job.IsRunByRunnerMatching(q.RunnerNamePattern) {
+		return false
+	}
+	return true
+}
+
+// RunFailure records a workflow run whose jobs could not be fetched
+type RunFailure struct {
+	RunID      int64
+	Repository string
+	Err        error
+}
+
+// JobHistoryResult is the outcome of FetchJobHistory
+type JobHistoryResult struct {
+	Jobs []*entity.Job
+	// Failures lists the runs whose jobs are missing from Jobs; the history is incomplete when non-empty
+	Failures []RunFailure
+}
+
+// JobLogResult is the outcome of FetchJobLog
+type JobLogResult struct {
+	// Text is the end of the log, at most the requested number of bytes
+	Text string
+	// Truncated reports whether the start of the log was dropped; Text may then start mid-line
+	Truncated bool
+}
+
 // JobRepository defines the interface for accessing job data
 type JobRepository interface {
 	// FetchJobHistory retrieves job history for a repository or organization
-	// If runnerID is provided (> 0), only jobs assigned to that runner are returned
-	FetchJobHistory(ctx context.Context, runnerID int64) ([]*entity.Job, error)
+	// Jobs are returned newest first (see entity.SortByStartedAtDesc), at most query.Limit of them.
+	FetchJobHistory(ctx context.Context, query JobQuery) (*JobHistoryResult, error)
+	// FetchJobLog retrieves the plain text log of a job, as shown by GitHub for its steps
+	// Logs are only available once the job has finished. Only the last maxBytes bytes of a longer
+	// log are kept, and never more than that is held in memory while reading it.
+	FetchJobLog(ctx context.Context, job *entity.Job, maxBytes int) (*JobLogResult, error)
 }
@@ -10,4 +10,8 @@ import (
 type RunnerRepository interface {
 	// FetchRunnerByName retrieves a specific runner by name
 	FetchRunnerByName(ctx context.Context, name string) (*entity.Runner, error)
+	// FetchRunners retrieves every runner in the repository or organization
+	FetchRunners(ctx context.Context) ([]*entity.Runner, error)
+	// FetchRunnersInGroup retrieves every runner in the named runner group
+	FetchRunnersInGroup(ctx context.Context, group string) ([]*entity.Runner, error)
 }
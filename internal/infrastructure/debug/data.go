@@ -40,28 +40,46 @@ type runnerRecord struct {
 	Labels []string `json:"labels"`
 	OS     string   `json:"os"`
 	Status string   `json:"status"`
+	Busy   bool     `json:"busy"`
+	Group  string   `json:"group"`
 }
 
 type jobRecord struct {
-	ID           int64      `json:"id"`
-	RunID        int64      `json:"run_id"`
-	RunAttempt   int        `json:"run_attempt"`
-	Name         string     `json:"name"`
-	Status       string     `json:"status"`
-	Conclusion   string     `json:"conclusion"`
-	RunnerID     *int64     `json:"runner_id"`
-	RunnerName   *string    `json:"runner_name"`
-	StartedAt    *time.Time `json:"started_at"`
-	CompletedAt  *time.Time `json:"completed_at"`
-	WorkflowName string     `json:"workflow_name"`
-	Repository   string     `json:"repository"`
-	HtmlURL      string     `json:"html_url"`
+	ID           int64        `json:"id"`
+	RunID        int64        `json:"run_id"`
+	RunAttempt   int          `json:"run_attempt"`
+	Name         string       `json:"name"`
+	Status       string       `json:"status"`
+	Conclusion   string       `json:"conclusion"`
+	RunnerID     *int64       `json:"runner_id"`
+	RunnerName   *string      `json:"runner_name"`
+	CreatedAt    *time.Time   `json:"created_at"`
+	StartedAt    *time.Time   `json:"started_at"`
+	CompletedAt  *time.Time   `json:"completed_at"`
+	WorkflowName string       `json:"workflow_name"`
+	Repository   string       `json:"repository"`
+	HtmlURL      string       `json:"html_url"`
+	Steps        []stepRecord `json:"steps"`
+	Log          string       `json:"log"`
+}
+
+type stepRecord struct {
+	Number      int        `json:"number"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
 }
 
 // dataset keeps parsed entities ready for repositories.
 type dataset struct {
 	runners []*entity.Runner
 	jobs    []*entity.Job
+	// logs maps job IDs to the logs recorded in the debug file
+	logs map[int64]string
+	// groups maps runner group names to the runners they contain
+	groups map[string][]*entity.Runner
 }
 
 func loadDataset(path string) (*dataset, error) {
@@ -82,16 +100,23 @@ func loadDataset(path string) (*dataset, error) {
 	ds := &dataset{
 		runners: make([]*entity.Runner, 0, len(raw.Runners)),
 		jobs:    make([]*entity.Job, 0, len(raw.Jobs)),
+		logs:    make(map[int64]string),
+		groups:  make(map[string][]*entity.Runner),
 	}
 
 	for _, r := range raw.Runners {
-		ds.runners = append(ds.runners, &entity.Runner{
+		runner := &entity.Runner{
 			ID:     r.ID,
 			Name:   r.Name,
 			Labels: append([]string(nil), r.Labels...),
 			OS:     r.OS,
 			Status: r.Status,
-		})
+			Busy:   r.Busy,
+		}
+		ds.runners = append(ds.runners, runner)
+		if r.Group != "" {
+			ds.groups[r.Group] = append(ds.groups[r.Group], runner)
+		}
 	}
 
 	for _, j := range raw.Jobs {
@@ -104,12 +129,26 @@ func loadDataset(path string) (*dataset, error) {
 			Conclusion:   j.Conclusion,
 			RunnerID:     j.RunnerID,
 			RunnerName:   j.RunnerName,
+			CreatedAt:    j.CreatedAt,
 			StartedAt:    j.StartedAt,
 			CompletedAt:  j.CompletedAt,
 			WorkflowName: j.WorkflowName,
 			Repository:   j.Repository,
 			HtmlUrl:      j.HtmlURL,
 		}
+		for _, st := range j.Steps {
+			job.Steps = append(job.Steps, entity.Step{
+				Number:      st.Number,
+				Name:        st.Name,
+				Status:      st.Status,
+				Conclusion:  st.Conclusion,
+				StartedAt:   st.StartedAt,
+				CompletedAt: st.CompletedAt,
+			})
+		}
+		if j.Log != "" {
+			ds.logs[j.ID] = j.Log
+		}
 		ds.jobs = append(ds.jobs, job)
 	}
 
@@ -2,8 +2,10 @@ package debug
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
 	domainrepo "github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
@@ -26,15 +28,15 @@ func NewJobRepository(ds *dataset, scope string, createdAfter time.Time) domainr
 	}
 }
 
-func (j *JobRepositoryImpl) FetchJobHistory(_ context.Context, runnerID int64) ([]*entity.Job, error) {
+func (j *JobRepositoryImpl) FetchJobHistory(_ context.Context, query domainrepo.JobQuery) (*domainrepo.JobHistoryResult, error) {
 	filtered := make([]*entity.Job, 0, len(j.ds.jobs))
 	for _, job := range j.ds.jobs {
 		if !j.matchScope(job.Repository) {
 			continue
 		}
 
-		// Filter by runner ID if specified
-		if runnerID > 0 && !job.IsAssignedToRunner(runnerID) {
+		// Filter by runner ID or name pattern if specified
+		if !query.Matches(job) {
 			continue
 		}
 
@@ -52,7 +54,30 @@ func (j *JobRepositoryImpl) FetchJobHistory(_ context.Context, runnerID int64) (
 		filtered = append(filtered, job)
 	}
 
-	return filtered, nil
+	entity.SortByStartedAtDesc(filtered)
+	if query.Limit > 0 && len(filtered) > query.Limit {
+		filtered = filtered[:query.Limit]
+	}
+
+	return &domainrepo.JobHistoryResult{Jobs: filtered}, nil
+}
+
+// FetchJobLog returns the end of the log recorded for the job in the debug file
+// Like the API, a longer log is cut at a byte offset and may start mid-line, but the cut never
+// splits a multi-byte character, so that the text stays valid UTF-8.
+func (j *JobRepositoryImpl) FetchJobLog(_ context.Context, job *entity.Job, maxBytes int) (*domainrepo.JobLogResult, error) {
+	log, ok := j.ds.logs[job.ID]
+	if !ok {
+		return nil, fmt.Errorf("debug file has no log for job %d", job.ID)
+	}
+	if len(log) > maxBytes {
+		start := len(log) - maxBytes
+		for start < len(log) && !utf8.RuneStart(log[start]) {
+			start++
+		}
+		return &domainrepo.JobLogResult{Text: log[start:], Truncated: true}, nil
+	}
+	return &domainrepo.JobLogResult{Text: log}, nil
 }
 
 // matchScope verifies that the repository string should be included for the given scope filter.
@@ -4,8 +4,10 @@ import (
 	"context"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	domainrepo "github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
 )
 
 func TestJobRepositoryImpl_FetchJobHistory_TimeFiltering(t *testing.T) {
@@ -72,10 +74,11 @@ func TestJobRepositoryImpl_FetchJobHistory_TimeFiltering(t *testing.T) {
 				createdAfter: tt.createdAfter,
 			}
 
-			result, err := repo.FetchJobHistory(context.Background(), runnerID)
+			res, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{RunnerIDs: []int64{runnerID}})
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
+			result := res.Jobs
 
 			if len(result) != len(tt.expectedIDs) {
 				t.Errorf("expected %d jobs, got %d", len(tt.expectedIDs), len(result))
@@ -146,10 +149,11 @@ func TestJobRepositoryImpl_FetchJobHistory_ScopeFiltering(t *testing.T) {
 				createdAfter: time.Time{}, // No time filter
 			}
 
-			result, err := repo.FetchJobHistory(context.Background(), runnerID)
+			res, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{RunnerIDs: []int64{runnerID}})
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
+			result := res.Jobs
 
 			if len(result) != len(tt.expectedIDs) {
 				t.Errorf("expected %d jobs, got %d", len(tt.expectedIDs), len(result))
@@ -182,22 +186,27 @@ func TestJobRepositoryImpl_FetchJobHistory_RunnerFiltering(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		runnerID    int64
+		runnerIDs   []int64
 		expectedIDs []int64
 	}{
 		{
 			name:        "filter by runner 123",
-			runnerID:    123,
+			runnerIDs:   []int64{123},
 			expectedIDs: []int64{1, 3},
 		},
 		{
 			name:        "filter by runner 456",
-			runnerID:    456,
+			runnerIDs:   []int64{456},
 			expectedIDs: []int64{2},
 		},
 		{
-			name:        "no runner filter (runnerID = 0)",
-			runnerID:    0,
+			name:        "filter by several runners",
+			runnerIDs:   []int64{123, 456},
+			expectedIDs: []int64{1, 2, 3},
+		},
+		{
+			name:        "no runner filter",
+			runnerIDs:   nil,
 			expectedIDs: []int64{1, 2, 3, 4},
 		},
 	}
@@ -211,10 +220,11 @@ func TestJobRepositoryImpl_FetchJobHistory_RunnerFiltering(t *testing.T) {
 				createdAfter: time.Time{},
 			}
 
-			result, err := repo.FetchJobHistory(context.Background(), tt.runnerID)
+			res, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{RunnerIDs: tt.runnerIDs})
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
+			result := res.Jobs
 
 			if len(result) != len(tt.expectedIDs) {
 				t.Errorf("expected %d jobs, got %d", len(tt.expectedIDs), len(result))
@@ -258,10 +268,11 @@ func TestJobRepositoryImpl_FetchJobHistory_CombinedFilters(t *testing.T) {
 	}
 
 	// Should match jobs: 1, 2 (runner1, acme-corp, within 30 hours)
-	result, err := repo.FetchJobHistory(context.Background(), runner1)
+	res, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{RunnerIDs: []int64{runner1}})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	result := res.Jobs
 
 	expectedIDs := []int64{1, 2}
 	if len(result) != len(expectedIDs) {
@@ -299,10 +310,11 @@ func TestJobRepositoryImpl_FetchJobHistory_NilStartedAt(t *testing.T) {
 		createdAfter: now.Add(-12 * time.Hour), // Last 12 hours
 	}
 
-	result, err := repo.FetchJobHistory(context.Background(), runnerID)
+	res, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{RunnerIDs: []int64{runnerID}})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	result := res.Jobs
 
 	// Should only match job 1 (job 2 has nil StartedAt and is excluded, job 3 is too old)
 	expectedIDs := []int64{1}
@@ -314,3 +326,60 @@ func TestJobRepositoryImpl_FetchJobHistory_NilStartedAt(t *testing.T) {
 		t.Errorf("expected job ID %d, got %d", expectedIDs[0], result[0].ID)
 	}
 }
+
+func TestJobRepositoryImpl_FetchJobHistory_Limit(t *testing.T) {
+	runnerID := int64(123)
+	base := time.Date(2025, 11, 20, 12, 0, 0, 0, time.UTC)
+
+	jobs := make([]*entity.Job, 0, 5)
+	for i := 0; i < 5; i++ {
+		started := base.Add(time.Duration(i) * time.Hour)
+		jobs = append(jobs, &entity.Job{ID: int64(i + 1), RunnerID: &runnerID, StartedAt: &started})
+	}
+
+	repo := &JobRepositoryImpl{ds: &dataset{jobs: jobs}}
+
+	res, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{RunnerIDs: []int64{runnerID}, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := res.Jobs
+
+	// Newest jobs first, limited to 2
+	expectedIDs := []int64{5, 4}
+	if len(result) != len(expectedIDs) {
+		t.Fatalf("expected %d jobs, got %d", len(expectedIDs), len(result))
+	}
+	for i, expectedID := range expectedIDs {
+		if result[i].ID != expectedID {
+			t.Errorf("at index %d: expected job ID %d, got %d", i, expectedID, result[i].ID)
+		}
+	}
+}
+
+func TestJobRepositoryImpl_FetchJobLog(t *testing.T) {
+	repo := &JobRepositoryImpl{ds: &dataset{logs: map[int64]string{1: "##[group]Run tests\nok\n##[endgroup]\n"}}}
+
+	log, err := repo.FetchJobLog(context.Background(), &entity.Job{ID: 1}, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if log.Text != "##[group]Run tests\nok\n##[endgroup]\n" || log.Truncated {
+		t.Errorf("unexpected log: %+v", log)
+	}
+
+	// Longer logs keep their end
+	if log, _ := repo.FetchJobLog(context.Background(), &entity.Job{ID: 1}, 16); log.Text != "ok\n##[endgroup]\n" || !log.Truncated {
+		t.Errorf("expected the end of the log, got %+v", log)
+	}
+
+	// The cut never splits a multi-byte character
+	repo.ds.logs[3] = "テスト開始\n完了\n"
+	if log, _ := repo.FetchJobLog(context.Background(), &entity.Job{ID: 3}, 12); log.Text != "始\n完了\n" || !log.Truncated || !utf8.ValidString(log.Text) {
+		t.Errorf("expected the end of the log at a character boundary, got %+v", log)
+	}
+
+	if _, err := repo.FetchJobLog(context.Background(), &entity.Job{ID: 2}, 1024); err == nil {
+		t.Error("expected an error for a job without a log")
+	}
+}
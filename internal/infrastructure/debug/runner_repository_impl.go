@@ -33,3 +33,17 @@ func (r *RunnerRepositoryImpl) FetchRunnerByName(_ context.Context, name string)
 	}
 	return nil, fmt.Errorf("runner '%s' not found in debug dataset", name)
 }
+
+// FetchRunners returns every runner in the dataset.
+func (r *RunnerRepositoryImpl) FetchRunners(_ context.Context) ([]*entity.Runner, error) {
+	return append([]*entity.Runner(nil), r.ds.runners...), nil
+}
+
+// FetchRunnersInGroup returns the runners whose "group" field matches the given name.
+func (r *RunnerRepositoryImpl) FetchRunnersInGroup(_ context.Context, group string) ([]*entity.Runner, error) {
+	runners, ok := r.ds.groups[group]
+	if !ok {
+		return nil, fmt.Errorf("runner group '%s' not found in debug dataset", group)
+	}
+	return append([]*entity.Runner(nil), runners...), nil
+}
@@ -0,0 +1,252 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Retry settings for rate-limited requests
+const (
+	maxRetries       = 5
+	initialBackoff   = time.Second
+	maxRetryWait     = 2 * time.Minute
+	rateLimitReserve = time.Second
+)
+
+// RateLimit is the API quota most recently reported by GitHub
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// ClientStats summarizes the requests made through a Client
+type ClientStats struct {
+	Requests  int
+	Retries   int
+	RateLimit *RateLimit
+}
+
+// Client wraps the go-gh REST client with rate-limit aware retries.
+// A single Client is shared by the repositories so they observe the same quota.
+type Client struct {
+	restClient *api.RESTClient
+	sleep      func(context.Context, time.Duration) error
+	now        func() time.Time
+
+	mu        sync.Mutex
+	requests  int
+	retries   int
+	rateLimit *RateLimit
+}
+
+// NewClient creates a Client authenticated with the gh CLI credentials
+func NewClient() (*Client, error) {
+	restClient, err := api.DefaultRESTClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create REST client: %w\nPlease run 'gh auth login' to authenticate with GitHub", err)
+	}
+	return newClient(restClient), nil
+}
+
+// newClient creates a Client around an existing REST client
+func newClient(restClient *api.RESTClient) *Client {
+	return &Client{
+		restClient: restClient,
+		sleep:      sleepContext,
+		now:        time.Now,
+	}
+}
+
+// Stats returns request counts and the last known rate limit
+func (c *Client) Stats() ClientStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := ClientStats{Requests: c.requests, Retries: c.retries}
+	if c.rateLimit != nil {
+		rl := *c.rateLimit
+		stats.RateLimit = &rl
+	}
+	return stats
+}
+
+// get issues a GET request and decodes the JSON response into v (see getRaw)
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	body, err := c.getRaw(ctx, path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// getRaw issues a GET request and returns the response body (see request)
+func (c *Client) getRaw(ctx context.Context, path string) ([]byte, error) {
+	resp, err := c.request(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// getTail issues a GET request and returns the last limit bytes of the response body, reporting
+// whether the start was dropped (see request and readTail)
+func (c *Client) getTail(ctx context.Context, path string, limit int) ([]byte, bool, error) {
+	resp, err := c.request(ctx, path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	return readTail(resp.Body, limit)
+}
+
+// readChunkSize is the initial size of the buffer readTail reads into
+const readChunkSize = 32 << 10
+
+// readTail reads r to the end and returns its last limit bytes, reporting whether anything was
+// dropped; the buffer it reads into never grows beyond twice limit, which must be positive
+func readTail(r io.Reader, limit int) ([]byte, bool, error) {
+	buf := make([]byte, 0, min(readChunkSize, 2*limit))
+	truncated := false
+	for {
+		if len(buf) == cap(buf) {
+			if len(buf) < 2*limit {
+				grown := make([]byte, len(buf), min(2*cap(buf), 2*limit))
+				copy(grown, buf)
+				buf = grown
+			} else {
+				// Drop the start only once the buffer is full, so that the log is moved rarely
+				buf = buf[:copy(buf, buf[len(buf)-limit:])]
+				truncated = true
+			}
+		}
+
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if len(buf) > limit {
+		buf = buf[len(buf)-limit:]
+		truncated = true
+	}
+	return buf, truncated, nil
+}
+
+// request issues a GET request and returns the response, whose body the caller must close.
+// Requests rejected by primary or secondary rate limits are retried after the
+// delay indicated by Retry-After or X-RateLimit-Reset, or with exponential backoff.
+// Cancelling ctx aborts both in-flight requests and waits between retries.
+func (c *Client) request(ctx context.Context, path string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		c.mu.Lock()
+		c.requests++
+		c.mu.Unlock()
+
+		resp, err := c.restClient.RequestWithContext(ctx, http.MethodGet, path, nil)
+		if err == nil {
+			c.recordRateLimit(resp.Header)
+			return resp, nil
+		}
+
+		var httpErr *api.HTTPError
+		if !errors.As(err, &httpErr) {
+			return nil, err
+		}
+		c.recordRateLimit(httpErr.Headers)
+
+		wait, limited := retryDelay(httpErr, attempt, c.now())
+		if !limited {
+			return nil, err
+		}
+		if attempt >= maxRetries || wait > maxRetryWait {
+			return nil, fmt.Errorf("rate limit exceeded, retry after %s: %w", c.now().Add(wait).Local().Format(time.Kitchen), err)
+		}
+
+		c.mu.Lock()
+		c.retries++
+		c.mu.Unlock()
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleepContext waits for d, returning early with the context error if ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// recordRateLimit stores the quota reported in the response headers, if any
+func (c *Client) recordRateLimit(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = &RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+// retryDelay reports whether the error is caused by a rate limit and how long to wait before retrying
+func retryDelay(httpErr *api.HTTPError, attempt int, now time.Time) (time.Duration, bool) {
+	if httpErr.StatusCode != http.StatusForbidden && httpErr.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	header := httpErr.Headers
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			wait := time.Unix(reset, 0).Sub(now) + rateLimitReserve
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+	}
+
+	// Secondary rate limits without explicit headers: back off exponentially
+	if httpErr.StatusCode == http.StatusTooManyRequests || strings.Contains(strings.ToLower(httpErr.Message), "rate limit") {
+		return initialBackoff * time.Duration(math.Pow(2, float64(attempt))), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,235 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// roundTripperFunc serves API requests in-process
+type roundTripperFunc func(*http.Request) *http.Response
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req), nil
+}
+
+// newTestClient returns a Client whose requests are answered by handler and which never sleeps
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	restClient, err := api.NewRESTClient(api.ClientOptions{
+		Host:         "github.com",
+		AuthToken:    "test-token",
+		LogIgnoreEnv: true,
+		Transport: roundTripperFunc(func(req *http.Request) *http.Response {
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+			resp := rec.Result()
+			resp.Request = req
+			return resp
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to create REST client: %v", err)
+	}
+
+	client := newClient(restClient)
+	client.sleep = func(context.Context, time.Duration) error { return nil }
+	return client
+}
+
+// writeJSONResponse encodes v as the response body
+func writeJSONResponse(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Fatalf("failed to encode response: %v", err)
+	}
+}
+
+// writeRateLimited responds with a rate limit error and the given headers
+func writeRateLimited(w http.ResponseWriter, status int, headers map[string]string) {
+	for k, v := range headers {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+}
+
+func TestClient_Get_RetriesRateLimitedRequests(t *testing.T) {
+	now := time.Date(2025, 11, 20, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		status       int
+		headers      map[string]string
+		expectedWait time.Duration
+	}{
+		{
+			name:         "secondary rate limit with Retry-After",
+			status:       http.StatusForbidden,
+			headers:      map[string]string{"Retry-After": "30"},
+			expectedWait: 30 * time.Second,
+		},
+		{
+			name:   "primary rate limit waits until reset",
+			status: http.StatusForbidden,
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(now.Add(20*time.Second).Unix(), 10),
+			},
+			expectedWait: 20*time.Second + rateLimitReserve,
+		},
+		{
+			name:         "too many requests without headers backs off",
+			status:       http.StatusTooManyRequests,
+			expectedWait: initialBackoff,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+				calls++
+				if calls == 1 {
+					writeRateLimited(w, tt.status, tt.headers)
+					return
+				}
+				w.Header().Set("X-RateLimit-Limit", "5000")
+				w.Header().Set("X-RateLimit-Remaining", "4321")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(time.Hour).Unix(), 10))
+				writeJSONResponse(t, w, map[string]int{"total_count": 1})
+			})
+			client.now = func() time.Time { return now }
+
+			var waits []time.Duration
+			client.sleep = func(_ context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			var resp struct {
+				TotalCount int `json:"total_count"`
+			}
+			if err := client.get(context.Background(), "repos/acme/app/actions/runs", &resp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.TotalCount != 1 {
+				t.Errorf("expected decoded response, got %+v", resp)
+			}
+			if len(waits) != 1 || waits[0] != tt.expectedWait {
+				t.Errorf("waits = %v, want [%v]", waits, tt.expectedWait)
+			}
+
+			stats := client.Stats()
+			if stats.Requests != 2 || stats.Retries != 1 {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+			if stats.RateLimit == nil || stats.RateLimit.Remaining != 4321 || stats.RateLimit.Limit != 5000 {
+				t.Errorf("expected quota from last response, got %+v", stats.RateLimit)
+			}
+		})
+	}
+}
+
+func TestClient_Get_GivesUpWhenResetIsTooFarAway(t *testing.T) {
+	now := time.Date(2025, 11, 20, 12, 0, 0, 0, time.UTC)
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		writeRateLimited(w, http.StatusForbidden, map[string]string{
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     strconv.FormatInt(now.Add(30*time.Minute).Unix(), 10),
+		})
+	})
+	client.now = func() time.Time { return now }
+
+	var resp struct{}
+	if err := client.get(context.Background(), "repos/acme/app/actions/runs", &resp); err == nil {
+		t.Fatal("expected rate limit error")
+	}
+	if stats := client.Stats(); stats.Requests != 1 {
+		t.Errorf("expected no retries, got %+v", stats)
+	}
+}
+
+func TestClient_Get_DoesNotRetryOtherErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+
+	var resp struct{}
+	if err := client.get(context.Background(), "repos/acme/missing/actions/runs", &resp); err == nil {
+		t.Fatal("expected error")
+	}
+	if stats := client.Stats(); stats.Requests != 1 || stats.Retries != 0 {
+		t.Errorf("expected a single request, got %+v", stats)
+	}
+}
+
+func TestClient_Get_RetryWaitHonorsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		writeRateLimited(w, http.StatusForbidden, map[string]string{"Retry-After": "60"})
+	})
+	client.sleep = sleepContext
+
+	var resp struct{}
+	start := time.Now()
+	err := client.get(ctx, "repos/acme/app/actions/runs", &resp)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected cancellation to interrupt the retry wait, took %v", elapsed)
+	}
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestReadTail(t *testing.T) {
+	const limit = 1000
+	body := strings.Repeat("0123456789", 100000)
+	r := &countingReader{r: strings.NewReader(body)}
+
+	tail, truncated, err := readTail(r, limit)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.n != len(body) {
+		t.Errorf("expected the whole body to be read, got %d of %d bytes", r.n, len(body))
+	}
+	if !truncated || string(tail) != body[len(body)-limit:] {
+		t.Errorf("expected the last %d bytes, got %d (truncated %v)", limit, len(tail), truncated)
+	}
+	if cap(tail) > 2*limit {
+		t.Errorf("expected at most %d bytes to be held, got a buffer of %d", 2*limit, cap(tail))
+	}
+
+	if tail, truncated, _ := readTail(strings.NewReader("short"), limit); truncated || string(tail) != "short" {
+		t.Errorf("expected a short body to be kept, got %q (truncated %v)", tail, truncated)
+	}
+}
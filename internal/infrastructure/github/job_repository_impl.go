@@ -4,100 +4,210 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
 	domainrepo "github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
+// jobsPerPage is the maximum page size supported by the workflow run jobs API
+const jobsPerPage = 100
+
 // JobRepositoryImpl implements the JobRepository interface using GitHub API
 type JobRepositoryImpl struct {
-	restClient   *api.RESTClient
+	client       *Client
 	basePath     string
 	createdAfter time.Time
+	concurrency  int
 }
 
 // NewJobRepository creates a new instance of JobRepositoryImpl
-func NewJobRepository(basePath string, createdAfter time.Time) (domainrepo.JobRepository, error) {
-	restClient, err := api.DefaultRESTClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create REST client: %w\nPlease run 'gh auth login' to authenticate with GitHub", err)
+// concurrency caps the number of workflow runs whose jobs are fetched at the same time.
+func NewJobRepository(client *Client, basePath string, createdAfter time.Time, concurrency int) domainrepo.JobRepository {
+	if concurrency < 1 {
+		concurrency = 1
 	}
 
 	return &JobRepositoryImpl{
-		restClient:   restClient,
+		client:       client,
 		basePath:     basePath,
 		createdAfter: createdAfter,
-	}, nil
+		concurrency:  concurrency,
+	}
 }
 
 // FetchJobHistory retrieves job history for a repository or organization
-// If runnerID is provided (> 0), only jobs assigned to that runner are returned
-func (j *JobRepositoryImpl) FetchJobHistory(ctx context.Context, runnerID int64) ([]*entity.Job, error) {
+// Only jobs matching the runner filters of the query are returned.
+// Workflow runs are listed newest first and handed to a pool of the configured concurrency,
+// so a run waiting out a rate limit does not hold up the others. Once query.Limit matching jobs
+// are found, the jobs of runs that cannot hold a job started after them are not fetched.
+// Runs whose jobs cannot be fetched are reported in the result's Failures, or fail the
+// whole request when query.Strict is set.
+func (j *JobRepositoryImpl) FetchJobHistory(ctx context.Context, query domainrepo.JobQuery) (*domainrepo.JobHistoryResult, error) {
 	var allJobs []*entity.Job
+	var failures []domainrepo.RunFailure
+
+	workCtx, cancel := context.WithCancel(ctx)
+	runs, results := j.startWorkers(workCtx)
+	defer func() {
+		// Stop the workers and wait for the runs still in flight
+		cancel()
+		close(runs)
+		for range results {
+		}
+	}()
 
 	path := j.getWorkflowRunsPath()
 	const perPage = 100
 	page := 1
+	morePages := true
+	var pending []workflowRun
+	inFlight := 0
+	// cutoff is the start time of the query.Limit-th newest job found so far, zero until then
+	var cutoff time.Time
 
-	// Fetch workflow runs page by page until we have enough jobs
 	for {
-		runs, err := j.fetchWorkflowRuns(path, perPage, page)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch workflow runs (page %d): %w", page, err)
+		// Runs interrupted by cancellation are not failures; stop altogether
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
 
-		// If no more runs, stop
-		if len(runs.WorkflowRuns) == 0 {
-			break
+		// Skip runs none of whose jobs can be among the newest ones found so far
+		for len(pending) > 0 && !mayHoldJobStartedAfter(pending[0], cutoff) {
+			pending = pending[1:]
 		}
 
-		// Fetch jobs for each run in parallel
-		type result struct {
-			jobs []*entity.Job
-			err  error
+		// Fetch workflow runs page by page
+		if len(pending) == 0 && morePages {
+			resp, err := j.fetchWorkflowRuns(ctx, path, perPage, page)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch workflow runs (page %d): %w", page, err)
+			}
+			pending = resp.WorkflowRuns
+			// If we got less than requested, we've reached the end
+			morePages = len(resp.WorkflowRuns) == perPage
+			page++
+			continue
 		}
 
-		results := make(chan result, len(runs.WorkflowRuns))
-
-		for _, run := range runs.WorkflowRuns {
-			go func(r workflowRun) {
-				jobs, err := j.getJobsForRun(r)
-				results <- result{jobs: jobs, err: err}
-			}(run)
+		// Hand out the next run
+		var send chan<- workflowRun
+		var next workflowRun
+		if len(pending) > 0 {
+			send = runs
+			next = pending[0]
+		}
+		if send == nil && inFlight == 0 {
+			break
 		}
 
-		// Collect results sequentially (no race condition)
-		for i := 0; i < len(runs.WorkflowRuns); i++ {
-			res := <-results
+		select {
+		case send <- next:
+			pending = pending[1:]
+			inFlight++
+		case res := <-results:
+			inFlight--
 			if res.err != nil {
-				// Skip runs that fail to fetch jobs - continue with partial data
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				if query.Strict {
+					return nil, res.err
+				}
+				// Record the failure and continue with partial data
+				failures = append(failures, domainrepo.RunFailure{
+					RunID:      res.run.ID,
+					Repository: res.run.Repository.FullName,
+					Err:        res.err,
+				})
 				continue
 			}
 
-			// Filter by runner ID if specified and append sequentially
-			if runnerID > 0 {
-				for _, job := range res.jobs {
-					if job.IsAssignedToRunner(runnerID) {
-						allJobs = append(allJobs, job)
-					}
+			// Filter by runner if specified
+			for _, job := range res.jobs {
+				if query.Matches(job) {
+					allJobs = append(allJobs, job)
 				}
-			} else {
-				allJobs = append(allJobs, res.jobs...)
 			}
+			cutoff = limitCutoff(allJobs, query.Limit)
 		}
+	}
 
-		// If we got less than requested, we've reached the end
-		if len(runs.WorkflowRuns) < perPage {
-			break
+	entity.SortByStartedAtDesc(allJobs)
+	if query.Limit > 0 && len(allJobs) > query.Limit {
+		allJobs = allJobs[:query.Limit]
+	}
+
+	return &domainrepo.JobHistoryResult{Jobs: allJobs, Failures: failures}, nil
+}
+
+// limitCutoff returns the start time of the limit-th most recently started job, or the zero
+// time when fewer jobs have started (a limit of 0 never has a cutoff)
+func limitCutoff(jobs []*entity.Job, limit int) time.Time {
+	if limit <= 0 {
+		return time.Time{}
+	}
+
+	var started []time.Time
+	for _, job := range jobs {
+		if job.StartedAt != nil {
+			started = append(started, *job.StartedAt)
 		}
+	}
+	if len(started) < limit {
+		return time.Time{}
+	}
+	sort.Slice(started, func(a, b int) bool { return started[a].After(started[b]) })
+	return started[limit-1]
+}
+
+// mayHoldJobStartedAfter reports whether a job of any attempt of the run may have started after
+// cutoff (always true for a zero cutoff)
+// The jobs of a completed run started before it was last updated; a run that is still queued or
+// in progress can start jobs at any time.
+func mayHoldJobStartedAfter(run workflowRun, cutoff time.Time) bool {
+	return cutoff.IsZero() || run.Status != entity.StatusCompleted || run.UpdatedAt.After(cutoff)
+}
 
-		page++
+// runJobsResult holds the jobs fetched for a single workflow run
+type runJobsResult struct {
+	run  workflowRun
+	jobs []*entity.Job
+	err  error
+}
+
+// startWorkers starts the configured number of workers fetching the jobs of the runs sent to
+// the returned channel. Each run gets a result, in the order the runs finish; the results
+// channel is closed once the runs channel is closed and every worker is done. Once ctx is
+// cancelled, runs are reported with the context error without being fetched.
+func (j *JobRepositoryImpl) startWorkers(ctx context.Context) (chan<- workflowRun, <-chan runJobsResult) {
+	runs := make(chan workflowRun)
+	results := make(chan runJobsResult)
+
+	var wg sync.WaitGroup
+	for w := 0; w < j.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for run := range runs {
+				if err := ctx.Err(); err != nil {
+					results <- runJobsResult{run: run, err: err}
+					continue
+				}
+				jobs, err := j.getJobsForRun(ctx, run)
+				results <- runJobsResult{run: run, jobs: jobs, err: err}
+			}
+		}()
 	}
 
-	return allJobs, nil
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return runs, results
 }
 
 // getWorkflowRunsPath constructs the API path for fetching workflow runs
@@ -106,7 +216,7 @@ func (j *JobRepositoryImpl) getWorkflowRunsPath() string {
 }
 
 // fetchWorkflowRuns fetches workflow runs from GitHub API with pagination
-func (j *JobRepositoryImpl) fetchWorkflowRuns(path string, perPage, page int) (*workflowRunsResponse, error) {
+func (j *JobRepositoryImpl) fetchWorkflowRuns(ctx context.Context, path string, perPage, page int) (*workflowRunsResponse, error) {
 	// Determine the separator for query parameters
 	separator := "?"
 	if strings.Contains(path, "?") {
@@ -123,7 +233,7 @@ func (j *JobRepositoryImpl) fetchWorkflowRuns(path string, perPage, page int) (*
 	}
 
 	var runs workflowRunsResponse
-	if err := j.restClient.Get(currentPath, &runs); err != nil {
+	if err := j.client.get(ctx, currentPath, &runs); err != nil {
 		return nil, fmt.Errorf("failed to fetch workflow runs: %w", err)
 	}
 
@@ -133,7 +243,9 @@ func (j *JobRepositoryImpl) fetchWorkflowRuns(path string, perPage, page int) (*
 // getJobsForRun fetches all jobs for a specific workflow run
 // Note: Jobs API always requires the specific repository path, even when querying org-scoped runs.
 // The run object contains the repository information, which we use to construct the path.
-func (j *JobRepositoryImpl) getJobsForRun(run workflowRun) ([]*entity.Job, error) {
+// Jobs from every attempt of the run are requested (filter=all) and all pages are followed,
+// so large matrices and retried jobs are included; RunAttempt tells the attempts apart.
+func (j *JobRepositoryImpl) getJobsForRun(ctx context.Context, run workflowRun) ([]*entity.Job, error) {
 	// Extract owner and repo from the run's repository information
 	if run.Repository.FullName == "" {
 		return nil, fmt.Errorf("workflow run %d has no repository information", run.ID)
@@ -149,29 +261,74 @@ func (j *JobRepositoryImpl) getJobsForRun(run workflowRun) ([]*entity.Job, error
 
 	path := fmt.Sprintf("%s/runs/%d/jobs", getRepoActionsBasePath(runOwner, runRepo), run.ID)
 
-	var jobsResp jobsResponse
-	if err := j.restClient.Get(path, &jobsResp); err != nil {
-		return nil, fmt.Errorf("failed to fetch jobs for run %d: %w", run.ID, err)
-	}
-
-	jobs := make([]*entity.Job, 0, len(jobsResp.Jobs))
-	for _, j := range jobsResp.Jobs {
-		jobs = append(jobs, &entity.Job{
-			ID:           j.ID,
-			RunID:        j.RunID,
-			RunAttempt:   j.RunAttempt,
-			Name:         j.Name,
-			Status:       j.Status,
-			Conclusion:   j.Conclusion,
-			RunnerID:     j.RunnerID,
-			RunnerName:   j.RunnerName,
-			StartedAt:    j.StartedAt,
-			CompletedAt:  j.CompletedAt,
-			WorkflowName: run.Name,
-			Repository:   run.Repository.FullName,
-			HtmlUrl:      j.HtmlUrl,
-		})
+	var jobs []*entity.Job
+	for page := 1; ; page++ {
+		currentPath := fmt.Sprintf("%s?filter=all&per_page=%d&page=%d", path, jobsPerPage, page)
+
+		var jobsResp jobsResponse
+		if err := j.client.get(ctx, currentPath, &jobsResp); err != nil {
+			return nil, fmt.Errorf("failed to fetch jobs for run %d (page %d): %w", run.ID, page, err)
+		}
+
+		for _, apiJob := range jobsResp.Jobs {
+			jobs = append(jobs, &entity.Job{
+				ID:           apiJob.ID,
+				RunID:        apiJob.RunID,
+				RunAttempt:   apiJob.RunAttempt,
+				Name:         apiJob.Name,
+				Status:       apiJob.Status,
+				Conclusion:   apiJob.Conclusion,
+				RunnerID:     apiJob.RunnerID,
+				RunnerName:   apiJob.RunnerName,
+				CreatedAt:    apiJob.CreatedAt,
+				StartedAt:    apiJob.StartedAt,
+				CompletedAt:  apiJob.CompletedAt,
+				WorkflowName: run.Name,
+				Repository:   run.Repository.FullName,
+				HtmlUrl:      apiJob.HtmlUrl,
+				Steps:        convertSteps(apiJob.Steps),
+			})
+		}
+
+		if len(jobsResp.Jobs) < jobsPerPage || len(jobs) >= jobsResp.TotalCount {
+			break
+		}
 	}
 
 	return jobs, nil
 }
+
+// FetchJobLog downloads the log of a job from its repository, keeping its last maxBytes bytes
+// The API redirects to a short-lived download URL, which the HTTP client follows.
+func (j *JobRepositoryImpl) FetchJobLog(ctx context.Context, job *entity.Job, maxBytes int) (*domainrepo.JobLogResult, error) {
+	parts := strings.Split(job.Repository, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("job %d has no valid repository: %q", job.ID, job.Repository)
+	}
+
+	path := fmt.Sprintf("%s/jobs/%d/logs", getRepoActionsBasePath(parts[0], parts[1]), job.ID)
+	body, truncated, err := j.client.getTail(ctx, path, maxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch log for job %d: %w", job.ID, err)
+	}
+	return &domainrepo.JobLogResult{Text: string(body), Truncated: truncated}, nil
+}
+
+// convertSteps converts the steps of a job from the API into entities
+func convertSteps(apiSteps []step) []entity.Step {
+	if len(apiSteps) == 0 {
+		return nil
+	}
+	steps := make([]entity.Step, len(apiSteps))
+	for i, s := range apiSteps {
+		steps[i] = entity.Step{
+			Number:      s.Number,
+			Name:        s.Name,
+			Status:      s.Status,
+			Conclusion:  s.Conclusion,
+			StartedAt:   s.StartedAt,
+			CompletedAt: s.CompletedAt,
+		}
+	}
+	return steps
+}
@@ -0,0 +1,426 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	domainrepo "github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
+)
+
+func TestJobRepositoryImpl_GetJobsForRun_AllPagesAndAttempts(t *testing.T) {
+	const totalJobs = 230
+	var requests []string
+
+	repo := &JobRepositoryImpl{
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			requests = append(requests, req.URL.Path+"?"+req.URL.RawQuery)
+			if req.URL.Query().Get("filter") != "all" {
+				t.Errorf("expected filter=all, got %q", req.URL.RawQuery)
+			}
+
+			page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+			perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+			resp := jobsResponse{TotalCount: totalJobs}
+			for i := (page-1)*perPage + 1; i <= page*perPage && i <= totalJobs; i++ {
+				// First half of the jobs belong to attempt 1, the rest to the retry
+				attempt := 1
+				if i > totalJobs/2 {
+					attempt = 2
+				}
+				resp.Jobs = append(resp.Jobs, job{ID: int64(i), RunID: 99, RunAttempt: attempt, Name: fmt.Sprintf("matrix (%d)", i)})
+			}
+			writeJSONResponse(t, w, resp)
+		}),
+	}
+
+	run := workflowRun{ID: 99, Name: "CI", Repository: repoInfo{FullName: "acme/app"}}
+	jobs, err := repo.getJobsForRun(context.Background(), run)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(jobs) != totalJobs {
+		t.Fatalf("expected %d jobs, got %d", totalJobs, len(jobs))
+	}
+	if len(requests) != 3 {
+		t.Errorf("expected 3 page requests, got %v", requests)
+	}
+	if requests[0] != "/repos/acme/app/actions/runs/99/jobs?filter=all&per_page=100&page=1" {
+		t.Errorf("unexpected request path: %s", requests[0])
+	}
+
+	attempts := map[int]int{}
+	for _, j := range jobs {
+		attempts[j.RunAttempt]++
+		if j.WorkflowName != "CI" || j.Repository != "acme/app" {
+			t.Fatalf("job %d missing run metadata: %+v", j.ID, j)
+		}
+	}
+	if attempts[1] != totalJobs/2 || attempts[2] != totalJobs/2 {
+		t.Errorf("expected jobs from both attempts, got %v", attempts)
+	}
+}
+
+func TestJobRepositoryImpl_GetJobsForRun_Steps(t *testing.T) {
+	repo := &JobRepositoryImpl{
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"total_count": 1, "jobs": [{"id": 1, "run_id": 99, "name": "build", "status": "completed", "steps": [
+				{"number": 1, "name": "Set up job", "status": "completed", "conclusion": "success", "started_at": "2025-11-15T10:00:00Z", "completed_at": "2025-11-15T10:00:02Z"},
+				{"number": 2, "name": "Run tests", "status": "completed", "conclusion": "failure", "started_at": "2025-11-15T10:00:02Z", "completed_at": "2025-11-15T10:04:02Z"}
+			]}]}`)
+		}),
+	}
+
+	jobs, err := repo.getJobsForRun(context.Background(), workflowRun{ID: 99, Name: "CI", Repository: repoInfo{FullName: "acme/app"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(jobs) != 1 || len(jobs[0].Steps) != 2 {
+		t.Fatalf("expected 1 job with 2 steps, got %+v", jobs)
+	}
+	step := jobs[0].Steps[1]
+	if step.Number != 2 || step.Name != "Run tests" || step.Conclusion != "failure" || step.GetExecutionDuration() != 4*time.Minute {
+		t.Errorf("unexpected step: %+v", step)
+	}
+}
+
+// newRunsResponse returns a page of runs of acme/app with the given IDs
+func newRunsResponse(ids ...int64) workflowRunsResponse {
+	resp := workflowRunsResponse{TotalCount: len(ids)}
+	for _, id := range ids {
+		resp.WorkflowRuns = append(resp.WorkflowRuns, workflowRun{ID: id, Repository: repoInfo{FullName: "acme/app"}})
+	}
+	return resp
+}
+
+func TestJobRepositoryImpl_FetchJobHistory_BoundsConcurrency(t *testing.T) {
+	const concurrency = 3
+	var inFlight, maxInFlight atomic.Int32
+
+	repo := &JobRepositoryImpl{
+		basePath:    "repos/acme/app/actions",
+		concurrency: concurrency,
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/repos/acme/app/actions/runs" {
+				writeJSONResponse(t, w, newRunsResponse(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20))
+				return
+			}
+
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				peak := maxInFlight.Load()
+				if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			var runID int64
+			_, _ = fmt.Sscanf(req.URL.Path, "/repos/acme/app/actions/runs/%d/jobs", &runID)
+			writeJSONResponse(t, w, jobsResponse{TotalCount: 1, Jobs: []job{{ID: runID, RunID: runID}}})
+		}),
+	}
+
+	result, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Jobs) != 20 {
+		t.Errorf("expected the jobs of all 20 runs, got %d", len(result.Jobs))
+	}
+	if peak := maxInFlight.Load(); peak > concurrency {
+		t.Errorf("expected at most %d concurrent requests, got %d", concurrency, peak)
+	}
+}
+
+func TestJobRepositoryImpl_FetchJobHistory_SlowRunDoesNotStallOthers(t *testing.T) {
+	var fetched atomic.Int32
+	othersDone := make(chan struct{})
+
+	repo := &JobRepositoryImpl{
+		basePath:    "repos/acme/app/actions",
+		concurrency: 2,
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/repos/acme/app/actions/runs" {
+				writeJSONResponse(t, w, newRunsResponse(1, 2, 3, 4, 5, 6))
+				return
+			}
+
+			var runID int64
+			_, _ = fmt.Sscanf(req.URL.Path, "/repos/acme/app/actions/runs/%d/jobs", &runID)
+			if runID == 1 {
+				// Run 1 is held back, as if waiting out a rate limit, until the other runs are done
+				select {
+				case <-othersDone:
+				case <-time.After(2 * time.Second):
+					t.Error("the other runs were not fetched while run 1 was waiting")
+				}
+			} else if fetched.Add(1) == 5 {
+				close(othersDone)
+			}
+			writeJSONResponse(t, w, jobsResponse{TotalCount: 1, Jobs: []job{{ID: runID, RunID: runID}}})
+		}),
+	}
+
+	result, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Jobs) != 6 {
+		t.Errorf("expected the jobs of all 6 runs, got %d", len(result.Jobs))
+	}
+}
+
+func TestJobRepositoryImpl_FetchJobHistory_StopsAtLimit(t *testing.T) {
+	var runPages, jobRequests atomic.Int32
+	runnerID := int64(42)
+	base := time.Date(2025, 11, 20, 12, 0, 0, 0, time.UTC)
+
+	repo := &JobRepositoryImpl{
+		basePath:    "repos/acme/app/actions",
+		concurrency: 5,
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/repos/acme/app/actions/runs" {
+				// Two pages of completed runs, each created a minute before the previous one
+				page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+				runPages.Add(1)
+				resp := workflowRunsResponse{TotalCount: 150}
+				for i := (page-1)*100 + 1; i <= page*100 && i <= 150; i++ {
+					created := base.Add(-time.Duration(i) * time.Minute)
+					resp.WorkflowRuns = append(resp.WorkflowRuns, workflowRun{
+						ID:         int64(i),
+						Status:     entity.StatusCompleted,
+						CreatedAt:  created,
+						UpdatedAt:  created.Add(40 * time.Second),
+						Repository: repoInfo{FullName: "acme/app"},
+					})
+				}
+				writeJSONResponse(t, w, resp)
+				return
+			}
+
+			jobRequests.Add(1)
+			var runID int64
+			_, _ = fmt.Sscanf(req.URL.Path, "/repos/acme/app/actions/runs/%d/jobs", &runID)
+			started := base.Add(-time.Duration(runID)*time.Minute + 30*time.Second)
+			writeJSONResponse(t, w, jobsResponse{TotalCount: 1, Jobs: []job{{ID: runID, RunID: runID, RunnerID: &runnerID, StartedAt: &started}}})
+		}),
+	}
+
+	result, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{RunnerIDs: []int64{runnerID}, Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	jobs := result.Jobs
+
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs, got %d", len(jobs))
+	}
+	for i, expectedID := range []int64{1, 2, 3} {
+		if jobs[i].ID != expectedID {
+			t.Errorf("at index %d: expected job %d, got %d", i, expectedID, jobs[i].ID)
+		}
+	}
+	if runPages.Load() != 2 {
+		t.Errorf("expected both pages of runs to be listed, got %d", runPages.Load())
+	}
+	// Runs already handed to the workers when the limit is reached are still fetched
+	if requests := jobRequests.Load(); requests > 3+5 {
+		t.Errorf("expected at most %d job requests, got %d", 3+5, requests)
+	}
+}
+
+func TestJobRepositoryImpl_FetchJobHistory_LimitWithLateStartingJobs(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 11, 20, hour, minute, 0, 0, time.UTC)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+	var requested []string
+	var mu sync.Mutex
+
+	repo := &JobRepositoryImpl{
+		basePath:    "repos/acme/app/actions",
+		concurrency: 1,
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			mu.Lock()
+			requested = append(requested, req.URL.Path)
+			mu.Unlock()
+			switch req.URL.Path {
+			case "/repos/acme/app/actions/runs":
+				writeJSONResponse(t, w, workflowRunsResponse{TotalCount: 4, WorkflowRuns: []workflowRun{
+					{ID: 1, Status: entity.StatusCompleted, CreatedAt: at(12, 0), UpdatedAt: at(12, 5), Repository: repoInfo{FullName: "acme/app"}},
+					{ID: 2, Status: entity.StatusCompleted, CreatedAt: at(11, 0), UpdatedAt: at(12, 40), Repository: repoInfo{FullName: "acme/app"}},
+					{ID: 3, Status: entity.StatusCompleted, CreatedAt: at(10, 0), UpdatedAt: at(13, 10), Repository: repoInfo{FullName: "acme/app"}},
+					{ID: 4, Status: entity.StatusCompleted, CreatedAt: at(9, 0), UpdatedAt: at(9, 10), Repository: repoInfo{FullName: "acme/app"}},
+				}})
+			case "/repos/acme/app/actions/runs/1/jobs":
+				writeJSONResponse(t, w, jobsResponse{TotalCount: 1, Jobs: []job{{ID: 10, RunID: 1, RunAttempt: 1, StartedAt: ptr(at(12, 1))}}})
+			case "/repos/acme/app/actions/runs/2/jobs":
+				// The second attempt of run 2 started after run 1's job
+				writeJSONResponse(t, w, jobsResponse{TotalCount: 2, Jobs: []job{
+					{ID: 20, RunID: 2, RunAttempt: 1, StartedAt: ptr(at(11, 1))},
+					{ID: 21, RunID: 2, RunAttempt: 2, StartedAt: ptr(at(12, 30))},
+				}})
+			case "/repos/acme/app/actions/runs/3/jobs":
+				// The job of run 3 sat queued and started after every other job
+				writeJSONResponse(t, w, jobsResponse{TotalCount: 1, Jobs: []job{{ID: 30, RunID: 3, RunAttempt: 1, StartedAt: ptr(at(13, 0))}}})
+			default:
+				writeJSONResponse(t, w, jobsResponse{TotalCount: 1, Jobs: []job{{ID: 40, RunID: 4, RunAttempt: 1, StartedAt: ptr(at(9, 1))}}})
+			}
+		}),
+	}
+
+	result, err := repo.FetchJobHistory(context.Background(), domainrepo.JobQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Jobs) != 2 || result.Jobs[0].ID != 30 || result.Jobs[1].ID != 21 {
+		t.Errorf("expected the late-starting job of run 3 and the re-run attempt of run 2, got %+v", result.Jobs)
+	}
+	for _, path := range requested {
+		if path == "/repos/acme/app/actions/runs/4/jobs" {
+			t.Error("expected run 4, finished before the newest jobs started, not to be fetched")
+		}
+	}
+}
+
+func TestJobRepositoryImpl_FetchJobHistory_ReportsFailedRuns(t *testing.T) {
+	newRepo := func() *JobRepositoryImpl {
+		return &JobRepositoryImpl{
+			basePath:    "orgs/acme/actions",
+			concurrency: 2,
+			client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/orgs/acme/actions/runs":
+					writeJSONResponse(t, w, workflowRunsResponse{TotalCount: 3, WorkflowRuns: []workflowRun{
+						{ID: 1, Repository: repoInfo{FullName: "acme/app"}},
+						{ID: 2, Repository: repoInfo{FullName: "acme/api"}},
+						{ID: 3, Repository: repoInfo{FullName: "acme/app"}},
+					}})
+				case "/repos/acme/api/actions/runs/2/jobs":
+					w.WriteHeader(http.StatusInternalServerError)
+					_, _ = w.Write([]byte(`{"message":"Server Error"}`))
+				default:
+					var runID int64
+					_, _ = fmt.Sscanf(req.URL.Path, "/repos/acme/app/actions/runs/%d/jobs", &runID)
+					writeJSONResponse(t, w, jobsResponse{TotalCount: 1, Jobs: []job{{ID: runID * 10, RunID: runID}}})
+				}
+			}),
+		}
+	}
+
+	result, err := newRepo().FetchJobHistory(context.Background(), domainrepo.JobQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Jobs) != 2 {
+		t.Errorf("expected jobs from the 2 healthy runs, got %d", len(result.Jobs))
+	}
+	if len(result.Failures) != 1 {
+		t.Fatalf("expected 1 failure, got %+v", result.Failures)
+	}
+	if failure := result.Failures[0]; failure.RunID != 2 || failure.Repository != "acme/api" || failure.Err == nil {
+		t.Errorf("unexpected failure: %+v", failure)
+	}
+
+	if _, err := newRepo().FetchJobHistory(context.Background(), domainrepo.JobQuery{Strict: true}); err == nil {
+		t.Error("expected strict mode to fail")
+	}
+}
+
+func TestJobRepositoryImpl_FetchJobHistory_StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var jobRequests atomic.Int32
+	repo := &JobRepositoryImpl{
+		basePath:    "repos/acme/app/actions",
+		concurrency: 1,
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/repos/acme/app/actions/runs" {
+				resp := workflowRunsResponse{TotalCount: 50}
+				for i := 1; i <= 50; i++ {
+					resp.WorkflowRuns = append(resp.WorkflowRuns, workflowRun{ID: int64(i), Repository: repoInfo{FullName: "acme/app"}})
+				}
+				writeJSONResponse(t, w, resp)
+				return
+			}
+
+			// The user quits while the first run's jobs are being fetched
+			jobRequests.Add(1)
+			cancel()
+			writeJSONResponse(t, w, jobsResponse{TotalCount: 1, Jobs: []job{{ID: 1}}})
+		}),
+	}
+
+	_, err := repo.FetchJobHistory(ctx, domainrepo.JobQuery{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if jobRequests.Load() != 1 {
+		t.Errorf("expected no requests after cancellation, got %d", jobRequests.Load())
+	}
+}
+
+func TestJobRepositoryImpl_FetchJobLog(t *testing.T) {
+	var requested string
+	repo := &JobRepositoryImpl{
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			requested = req.URL.Path
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "2025-11-15T10:00:00.0000000Z ##[group]Run tests\n")
+		}),
+		// Logs are fetched from the job's repository, even for organization scopes
+		basePath: "orgs/acme/actions",
+	}
+
+	log, err := repo.FetchJobLog(context.Background(), &entity.Job{ID: 7, Repository: "acme/app"}, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requested != "/repos/acme/app/actions/jobs/7/logs" {
+		t.Errorf("unexpected path: %s", requested)
+	}
+	if log.Text != "2025-11-15T10:00:00.0000000Z ##[group]Run tests\n" || log.Truncated {
+		t.Errorf("unexpected log: %+v", log)
+	}
+
+	if _, err := repo.FetchJobLog(context.Background(), &entity.Job{ID: 7}, 1024); err == nil {
+		t.Error("expected an error for a job without a repository")
+	}
+}
+
+func TestJobRepositoryImpl_FetchJobLog_KeepsTheEndOfLongLogs(t *testing.T) {
+	const maxBytes = 4096
+	var written int
+	repo := &JobRepositoryImpl{
+		client: newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			for i := 0; i < 100000; i++ {
+				n, _ := fmt.Fprintf(w, "line %06d\n", i)
+				written += n
+			}
+		}),
+	}
+
+	log, err := repo.FetchJobLog(context.Background(), &entity.Job{ID: 7, Repository: "acme/app"}, maxBytes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !log.Truncated || len(log.Text) != maxBytes || !strings.HasSuffix(log.Text, "line 099999\n") {
+		t.Errorf("expected the last %d of %d bytes, got %d bytes (truncated %v) ending %q",
+			maxBytes, written, len(log.Text), log.Truncated, log.Text[max(0, len(log.Text)-12):])
+	}
+}
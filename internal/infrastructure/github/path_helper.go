@@ -1,6 +1,9 @@
 package github
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // GetActionsBasePath returns the base path for GitHub Actions API
 // Returns "orgs/{org}/actions" for organization scope or "repos/{owner}/{repo}/actions" for repository scope
@@ -16,3 +19,17 @@ func GetActionsBasePath(owner, repo, org string) string {
 func getRepoActionsBasePath(owner, repo string) string {
 	return fmt.Sprintf("repos/%s/%s/actions", owner, repo)
 }
+
+// describeScope returns a human readable description of an Actions base path,
+// such as "organization 'acme'" or "repository 'acme/app'"
+func describeScope(basePath string) string {
+	parts := strings.Split(strings.TrimSuffix(basePath, "/actions"), "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "orgs":
+		return fmt.Sprintf("organization '%s'", parts[1])
+	case len(parts) == 3 && parts[0] == "repos":
+		return fmt.Sprintf("repository '%s/%s'", parts[1], parts[2])
+	default:
+		return basePath
+	}
+}
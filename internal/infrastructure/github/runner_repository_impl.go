@@ -3,61 +3,165 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
 	domainrepo "github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
-	"github.com/cli/go-gh/v2/pkg/api"
 )
 
+// runnersPerPage is the maximum page size supported by the runners API
+const runnersPerPage = 100
+
 // RunnerRepositoryImpl implements the RunnerRepository interface using GitHub API
 type RunnerRepositoryImpl struct {
-	restClient *api.RESTClient
-	basePath   string
+	client   *Client
+	basePath string
 }
 
 // NewRunnerRepository creates a new instance of RunnerRepositoryImpl
-func NewRunnerRepository(basePath string) (domainrepo.RunnerRepository, error) {
-	restClient, err := api.DefaultRESTClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create REST client: %w\nPlease run 'gh auth login' to authenticate with GitHub", err)
-	}
-
+func NewRunnerRepository(client *Client, basePath string) domainrepo.RunnerRepository {
 	return &RunnerRepositoryImpl{
-		restClient: restClient,
-		basePath:   basePath,
-	}, nil
+		client:   client,
+		basePath: basePath,
+	}
 }
 
 // FetchRunnerByName retrieves a specific runner by name
+// The runners API is filtered by name and every page of the result is walked.
 func (r *RunnerRepositoryImpl) FetchRunnerByName(ctx context.Context, name string) (*entity.Runner, error) {
-	path := r.getRunnersPath()
+	fetched := 0
+	for page := 1; ; page++ {
+		runnersResp, err := r.fetchRunnersPage(ctx, name, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch runners (page %d): %w", page, err)
+		}
 
-	var runnersResp runnersResponse
-	if err := r.restClient.Get(path, &runnersResp); err != nil {
+		for _, runner := range runnersResp.Runners {
+			if runner.Name == name {
+				return toRunnerEntity(runner), nil
+			}
+		}
+
+		fetched += len(runnersResp.Runners)
+		if len(runnersResp.Runners) < runnersPerPage || fetched >= runnersResp.TotalCount {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("runner '%s' not found in %s", name, describeScope(r.basePath))
+}
+
+// FetchRunners retrieves every runner in the repository or organization
+func (r *RunnerRepositoryImpl) FetchRunners(ctx context.Context) ([]*entity.Runner, error) {
+	runners, err := r.fetchAllRunners(ctx, r.getRunnersPath())
+	if err != nil {
 		return nil, fmt.Errorf("failed to fetch runners: %w", err)
 	}
+	return runners, nil
+}
+
+// FetchRunnersInGroup retrieves every runner in the named organization runner group
+func (r *RunnerRepositoryImpl) FetchRunnersInGroup(ctx context.Context, group string) ([]*entity.Runner, error) {
+	if !strings.HasPrefix(r.basePath, "orgs/") {
+		return nil, fmt.Errorf("runner groups are only available for organizations, use --org")
+	}
+
+	groupID, err := r.findRunnerGroupID(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	runners, err := r.fetchAllRunners(ctx, fmt.Sprintf("%s/runner-groups/%d/runners", r.basePath, groupID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch runners in group '%s': %w", group, err)
+	}
+	return runners, nil
+}
+
+// findRunnerGroupID looks up the ID of a runner group by name
+func (r *RunnerRepositoryImpl) findRunnerGroupID(ctx context.Context, group string) (int64, error) {
+	searched := 0
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("%s/runner-groups?per_page=%d&page=%d", r.basePath, runnersPerPage, page)
+
+		var groupsResp runnerGroupsResponse
+		if err := r.client.get(ctx, path, &groupsResp); err != nil {
+			return 0, fmt.Errorf("failed to fetch runner groups (page %d): %w", page, err)
+		}
 
-	for _, runner := range runnersResp.Runners {
-		if runner.Name == name {
-			labels := make([]string, 0, len(runner.Labels))
-			for _, l := range runner.Labels {
-				labels = append(labels, l.Name)
+		for _, g := range groupsResp.RunnerGroups {
+			if g.Name == group {
+				return g.ID, nil
 			}
+		}
 
-			return &entity.Runner{
-				ID:     runner.ID,
-				Name:   runner.Name,
-				OS:     runner.OS,
-				Status: runner.Status,
-				Labels: labels,
-			}, nil
+		searched += len(groupsResp.RunnerGroups)
+		if len(groupsResp.RunnerGroups) < runnersPerPage || searched >= groupsResp.TotalCount {
+			break
 		}
 	}
 
-	return nil, fmt.Errorf("runner '%s' not found in the repository", name)
+	return 0, fmt.Errorf("runner group '%s' not found in %s", group, describeScope(r.basePath))
+}
+
+// fetchAllRunners walks every page of a runners listing
+func (r *RunnerRepositoryImpl) fetchAllRunners(ctx context.Context, path string) ([]*entity.Runner, error) {
+	var runners []*entity.Runner
+	for page := 1; ; page++ {
+		var runnersResp runnersResponse
+		if err := r.client.get(ctx, runnersPagePath(path, "", page), &runnersResp); err != nil {
+			return nil, fmt.Errorf("page %d: %w", page, err)
+		}
+
+		for _, runner := range runnersResp.Runners {
+			runners = append(runners, toRunnerEntity(runner))
+		}
+
+		if len(runnersResp.Runners) < runnersPerPage || len(runners) >= runnersResp.TotalCount {
+			return runners, nil
+		}
+	}
+}
+
+// fetchRunnersPage fetches a single page of runners, optionally filtered by name
+func (r *RunnerRepositoryImpl) fetchRunnersPage(ctx context.Context, name string, page int) (*runnersResponse, error) {
+	var runnersResp runnersResponse
+	if err := r.client.get(ctx, runnersPagePath(r.getRunnersPath(), name, page), &runnersResp); err != nil {
+		return nil, err
+	}
+	return &runnersResp, nil
 }
 
 // getRunnersPath constructs the API path for fetching runners
 func (r *RunnerRepositoryImpl) getRunnersPath() string {
-	return r.basePath + "/runners?per_page=100"
+	return r.basePath + "/runners"
+}
+
+// runnersPagePath adds the name filter and pagination parameters to a runners listing path
+func runnersPagePath(path, name string, page int) string {
+	query := url.Values{}
+	if name != "" {
+		query.Set("name", name)
+	}
+	query.Set("per_page", fmt.Sprintf("%d", runnersPerPage))
+	query.Set("page", fmt.Sprintf("%d", page))
+	return path + "?" + query.Encode()
+}
+
+// toRunnerEntity converts an API runner into a domain entity
+func toRunnerEntity(runner runner) *entity.Runner {
+	labels := make([]string, 0, len(runner.Labels))
+	for _, l := range runner.Labels {
+		labels = append(labels, l.Name)
+	}
+
+	return &entity.Runner{
+		ID:     runner.ID,
+		Name:   runner.Name,
+		OS:     runner.OS,
+		Status: runner.Status,
+		Busy:   runner.Busy,
+		Labels: labels,
+	}
 }
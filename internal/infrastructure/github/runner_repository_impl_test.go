@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// runnerPages serves totalRunners runners named runner-1..runner-N across pages, keeping only the
+// runner with the requested name when the name filter is set
+func runnerPages(t *testing.T, totalRunners int, requestedPages *[]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+		name := req.URL.Query().Get("name")
+		*requestedPages = append(*requestedPages, page)
+
+		var matching []runner
+		for i := 1; i <= totalRunners; i++ {
+			r := runner{
+				ID:     int64(i),
+				Name:   fmt.Sprintf("runner-%d", i),
+				Labels: []label{{Name: "self-hosted"}},
+			}
+			if name == "" || r.Name == name {
+				matching = append(matching, r)
+			}
+		}
+
+		resp := runnersResponse{TotalCount: len(matching)}
+		for i := (page - 1) * perPage; i < page*perPage && i < len(matching); i++ {
+			resp.Runners = append(resp.Runners, matching[i])
+		}
+		writeJSONResponse(t, w, resp)
+	}
+}
+
+func TestRunnerRepositoryImpl_FetchRunnerByName(t *testing.T) {
+	var pages []int
+	repo := &RunnerRepositoryImpl{
+		client:   newTestClient(t, runnerPages(t, 412, &pages)),
+		basePath: "orgs/acme/actions",
+	}
+
+	runner, err := repo.FetchRunnerByName(context.Background(), "runner-350")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runner.ID != 350 {
+		t.Errorf("expected runner 350, got %d", runner.ID)
+	}
+	if len(runner.Labels) != 1 || runner.Labels[0] != "self-hosted" {
+		t.Errorf("unexpected labels: %v", runner.Labels)
+	}
+	if len(pages) != 1 {
+		t.Errorf("expected the name filter to need a single page, requested pages %v", pages)
+	}
+}
+
+func TestRunnerRepositoryImpl_FetchRunners_Paginates(t *testing.T) {
+	var pages []int
+	repo := &RunnerRepositoryImpl{
+		client:   newTestClient(t, runnerPages(t, 412, &pages)),
+		basePath: "orgs/acme/actions",
+	}
+
+	runners, err := repo.FetchRunners(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runners) != 412 || runners[411].Name != "runner-412" {
+		t.Errorf("expected all 412 runners, got %d", len(runners))
+	}
+	if len(pages) != 5 {
+		t.Errorf("expected 5 pages, requested pages %v", pages)
+	}
+}
+
+func TestRunnerRepositoryImpl_FetchRunnerByName_UsesNameFilter(t *testing.T) {
+	var query string
+	repo := &RunnerRepositoryImpl{
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			query = req.URL.RawQuery
+			writeJSONResponse(t, w, runnersResponse{TotalCount: 1, Runners: []runner{{ID: 7, Name: "gpu runner"}}})
+		}),
+		basePath: "repos/acme/app/actions",
+	}
+
+	if _, err := repo.FetchRunnerByName(context.Background(), "gpu runner"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(query, "name=gpu+runner") {
+		t.Errorf("expected name filter in query, got %q", query)
+	}
+}
+
+func TestRunnerRepositoryImpl_FetchRunnerByName_NotFoundReportsScope(t *testing.T) {
+	var pages []int
+	repo := &RunnerRepositoryImpl{
+		client:   newTestClient(t, runnerPages(t, 250, &pages)),
+		basePath: "orgs/acme/actions",
+	}
+
+	_, err := repo.FetchRunnerByName(context.Background(), "missing")
+	if err == nil {
+		t.Fatal("expected not found error")
+	}
+	expected := "runner 'missing' not found in organization 'acme'"
+	if err.Error() != expected {
+		t.Errorf("error = %q, want %q", err.Error(), expected)
+	}
+	if len(pages) != 1 {
+		t.Errorf("expected a single page for the name filter, requested pages %v", pages)
+	}
+}
+
+func TestRunnerRepositoryImpl_FetchRunnersInGroup(t *testing.T) {
+	var paths []string
+	repo := &RunnerRepositoryImpl{
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			paths = append(paths, req.URL.Path)
+			switch req.URL.Path {
+			case "/orgs/acme/actions/runner-groups":
+				writeJSONResponse(t, w, runnerGroupsResponse{TotalCount: 2, RunnerGroups: []runnerGroup{
+					{ID: 1, Name: "Default"},
+					{ID: 9, Name: "gpu"},
+				}})
+			case "/orgs/acme/actions/runner-groups/9/runners":
+				writeJSONResponse(t, w, runnersResponse{TotalCount: 2, Runners: []runner{
+					{ID: 3, Name: "gpu-1"},
+					{ID: 4, Name: "gpu-2"},
+				}})
+			default:
+				t.Errorf("unexpected request path %s", req.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+		basePath: "orgs/acme/actions",
+	}
+
+	runners, err := repo.FetchRunnersInGroup(context.Background(), "gpu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runners) != 2 || runners[0].Name != "gpu-1" || runners[1].Name != "gpu-2" {
+		t.Errorf("unexpected runners: %+v", runners)
+	}
+
+	if _, err := repo.FetchRunnersInGroup(context.Background(), "missing"); err == nil || !strings.Contains(err.Error(), "organization 'acme'") {
+		t.Errorf("expected not found error mentioning the organization, got %v", err)
+	}
+}
+
+func TestRunnerRepositoryImpl_FetchRunnersInGroup_RequiresOrganization(t *testing.T) {
+	repo := &RunnerRepositoryImpl{
+		client: newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+			t.Errorf("unexpected request %s", req.URL)
+		}),
+		basePath: "repos/acme/app/actions",
+	}
+
+	if _, err := repo.FetchRunnersInGroup(context.Background(), "gpu"); err == nil {
+		t.Fatal("expected error for repository scope")
+	}
+}
@@ -47,11 +47,23 @@ type job struct {
 	Name        string     `json:"name"`
 	Status      string     `json:"status"`
 	Conclusion  string     `json:"conclusion"`
+	CreatedAt   *time.Time `json:"created_at"`
 	StartedAt   *time.Time `json:"started_at"`
 	CompletedAt *time.Time `json:"completed_at"`
 	RunnerID    *int64     `json:"runner_id"`
 	RunnerName  *string    `json:"runner_name"`
 	HtmlUrl     string     `json:"html_url"`
+	Steps       []step     `json:"steps"`
+}
+
+// step represents a single step of a job
+type step struct {
+	Number      int        `json:"number"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
 }
 
 // runnersResponse represents the response from GitHub API for runners
@@ -66,6 +78,7 @@ type runner struct {
 	Name   string  `json:"name"`
 	OS     string  `json:"os"`
 	Status string  `json:"status"`
+	Busy   bool    `json:"busy"`
 	Labels []label `json:"labels"`
 }
 
@@ -75,3 +88,15 @@ type label struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
 }
+
+// runnerGroupsResponse represents the response from GitHub API for runner groups
+type runnerGroupsResponse struct {
+	TotalCount   int           `json:"total_count"`
+	RunnerGroups []runnerGroup `json:"runner_groups"`
+}
+
+// runnerGroup represents a single organization runner group
+type runnerGroup struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
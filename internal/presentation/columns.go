@@ -0,0 +1,177 @@
+package presentation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+)
+
+// jobColumn describes a job field that can be rendered in the table or exported
+type jobColumn struct {
+	key   string
+	title string
+	value func(job *entity.Job) string
+	// export replaces value in CSV and TSV when the table shows the field for people to read
+	export func(job *entity.Job) string
+}
+
+// exportValue formats the field of the job for CSV and TSV
+func (c jobColumn) exportValue(job *entity.Job) string {
+	if c.export != nil {
+		return c.export(job)
+	}
+	return c.value(job)
+}
+
+// jobColumns lists every column that can be selected with --columns
+var jobColumns = []jobColumn{
+	{key: "workflow", title: "Workflow", value: func(job *entity.Job) string { return job.WorkflowName }},
+	{key: "job", title: "Job", value: func(job *entity.Job) string { return job.Name }},
+	{key: "attempt", title: "Attempt", value: func(job *entity.Job) string { return strconv.Itoa(job.RunAttempt) }},
+	{key: "status", title: "Status", value: func(job *entity.Job) string { return job.Status }},
+	{key: "conclusion", title: "Conclusion", value: formatConclusion},
+	{key: "queued", title: "Queued", value: formatQueueDuration},
+	{key: "queued_seconds", title: "Queued Seconds", value: formatQueueSeconds},
+	{
+		key:    "started_at",
+		title:  "Started At",
+		value:  func(job *entity.Job) string { return formatTime(job.StartedAt) },
+		export: func(job *entity.Job) string { return formatExportTime(job.StartedAt) },
+	},
+	{key: "duration", title: "Duration", value: formatJobDuration},
+	{key: "duration_seconds", title: "Duration Seconds", value: formatDurationSeconds},
+	{
+		key:    "completed_at",
+		title:  "Completed At",
+		value:  func(job *entity.Job) string { return formatTime(job.CompletedAt) },
+		export: func(job *entity.Job) string { return formatExportTime(job.CompletedAt) },
+	},
+	{key: "job_id", title: "Job ID", value: func(job *entity.Job) string { return strconv.FormatInt(job.ID, 10) }},
+	{key: "run_id", title: "Run ID", value: func(job *entity.Job) string { return strconv.FormatInt(job.RunID, 10) }},
+	{key: "repository", title: "Repository", value: func(job *entity.Job) string { return job.Repository }},
+	{key: "runner", title: "Runner", value: formatRunnerName},
+	{key: "url", title: "URL", value: func(job *entity.Job) string { return job.HtmlUrl }},
+}
+
+// defaultColumnKeys are the columns shown in the interactive table
+var defaultColumnKeys = []string{"workflow", "job", "attempt", "status", "conclusion", "queued", "started_at", "duration"}
+
+// defaultExportColumnKeys are the columns written to CSV and TSV, the table columns with durations in seconds
+var defaultExportColumnKeys = []string{"workflow", "job", "attempt", "status", "conclusion", "queued_seconds", "started_at", "duration_seconds"}
+
+// tableColumnKeys returns the interactive table columns, adding the runner when jobs span several runners
+func tableColumnKeys(showRunner bool) []string {
+	if !showRunner {
+		return defaultColumnKeys
+	}
+	keys := append([]string{}, defaultColumnKeys[:2]...)
+	keys = append(keys, "runner")
+	return append(keys, defaultColumnKeys[2:]...)
+}
+
+// selectColumns resolves column keys to column definitions, using the export defaults when keys is empty
+func selectColumns(keys []string) ([]jobColumn, error) {
+	if len(keys) == 0 {
+		keys = defaultExportColumnKeys
+	}
+
+	columns := make([]jobColumn, 0, len(keys))
+	for _, key := range keys {
+		col, ok := findColumn(strings.TrimSpace(key))
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", key, strings.Join(columnKeys(), ", "))
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+// findColumn looks up a column definition by key
+func findColumn(key string) (jobColumn, bool) {
+	for _, col := range jobColumns {
+		if col.key == key {
+			return col, true
+		}
+	}
+	return jobColumn{}, false
+}
+
+// columnKeys returns the keys of all selectable columns
+func columnKeys() []string {
+	keys := make([]string, len(jobColumns))
+	for i, col := range jobColumns {
+		keys[i] = col.key
+	}
+	return keys
+}
+
+// formatTime formats an optional timestamp in local time
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05 MST")
+}
+
+// formatExportTime formats an optional timestamp as RFC 3339 in UTC, or empty if unknown
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatJobDuration formats the execution time of a job, including jobs still running
+func formatJobDuration(job *entity.Job) string {
+	if job.CompletedAt != nil && job.StartedAt != nil {
+		return formatDuration(job.GetExecutionDuration())
+	}
+	if job.StartedAt != nil && job.Status == entity.StatusInProgress {
+		return formatDuration(time.Since(*job.StartedAt)) + " (running)"
+	}
+	return "-"
+}
+
+// formatQueueDuration formats how long the job waited for a runner, or "-" if unknown
+func formatQueueDuration(job *entity.Job) string {
+	if job.CreatedAt == nil || job.StartedAt == nil {
+		return "-"
+	}
+	return formatDuration(job.GetQueueDuration())
+}
+
+// formatDurationSeconds formats the execution time of a finished job in whole seconds, or
+// empty if the job has not finished
+func formatDurationSeconds(job *entity.Job) string {
+	if job.StartedAt == nil || job.CompletedAt == nil {
+		return ""
+	}
+	return strconv.FormatInt(int64(job.GetExecutionDuration().Seconds()), 10)
+}
+
+// formatQueueSeconds formats how long the job waited for a runner in whole seconds, or empty if unknown
+func formatQueueSeconds(job *entity.Job) string {
+	if job.CreatedAt == nil || job.StartedAt == nil {
+		return ""
+	}
+	return strconv.FormatInt(int64(job.GetQueueDuration().Seconds()), 10)
+}
+
+// formatConclusion returns the job conclusion or "-" if the job has not concluded
+func formatConclusion(job *entity.Job) string {
+	if job.Conclusion == "" {
+		return "-"
+	}
+	return job.Conclusion
+}
+
+// formatRunnerName returns the name of the runner that picked up the job
+func formatRunnerName(job *entity.Job) string {
+	if job.RunnerName == nil || *job.RunnerName == "" {
+		return "-"
+	}
+	return *job.RunnerName
+}
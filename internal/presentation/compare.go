@@ -0,0 +1,300 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// Fixed widths of the comparison table columns; Job takes the remaining width
+const (
+	compareRunsWidth   = 9
+	compareMedianWidth = 9
+	compareChangeWidth = 7
+	comparePWidth      = 7
+	compareFailWidth   = 6
+	minCompareJobWidth = 20
+)
+
+// RunCompare fetches the jobs of both runners since the given time and writes the comparison
+// as a table, or as JSON with FormatJSON; differences whose p-value, adjusted for every test of
+// the comparison, is below alpha are marked
+func (c *Controller) RunCompare(ctx context.Context, nameA, nameB string, historyOpts usecase.HistoryOptions, since time.Time, alpha float64) error {
+	switch c.opts.Format {
+	case FormatTUI, FormatJSON:
+	default:
+		return fmt.Errorf("unsupported output format %q for the comparison", c.opts.Format)
+	}
+
+	comparison, err := c.runnerLogger.FetchRunnerComparison(ctx, nameA, nameB, historyOpts, since)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.Format == FormatJSON {
+		err = writeDocument(c.opts, newJSONComparison(comparison, alpha))
+	} else {
+		err = writeComparisonReport(c.opts.Out, comparison, alpha, terminalWidth())
+	}
+	if err != nil {
+		return err
+	}
+
+	if comparison.IsPartial() {
+		writeFailureSummary(c.opts.ErrOut, comparison.Failures)
+		return fmt.Errorf("comparison is incomplete: %s", describeFailures(comparison.Failures))
+	}
+	return nil
+}
+
+// jsonComparison is the JSON document written by the compare subcommand with --json
+type jsonComparison struct {
+	RunnerA  *jsonRunner         `json:"runner_a"`
+	RunnerB  *jsonRunner         `json:"runner_b"`
+	From     time.Time           `json:"from"`
+	To       time.Time           `json:"to"`
+	Alpha    float64             `json:"alpha"`
+	Tests    int                 `json:"tests"`
+	Jobs     []jsonJobComparison `json:"jobs"`
+	OnlyA    int                 `json:"only_a"`
+	OnlyB    int                 `json:"only_b"`
+	Failures []jsonFailure       `json:"failures,omitempty"`
+}
+
+// jsonJobComparison is the JSON form of usecase.JobComparison
+type jsonJobComparison struct {
+	Repository   string        `json:"repository"`
+	WorkflowName string        `json:"workflow_name"`
+	Name         string        `json:"name"`
+	A            jsonJobSample `json:"a"`
+	B            jsonJobSample `json:"b"`
+	// The p-values are null when the test cannot be run
+	DurationP           *float64 `json:"duration_p_value"`
+	DurationAdjustedP   *float64 `json:"duration_p_value_adjusted"`
+	DurationSignificant bool     `json:"duration_significant"`
+	FailureP            *float64 `json:"failure_p_value"`
+	FailureAdjustedP    *float64 `json:"failure_p_value_adjusted"`
+	FailureSignificant  bool     `json:"failure_significant"`
+}
+
+// jsonJobSample is the JSON form of usecase.JobSample
+type jsonJobSample struct {
+	Runs   int `json:"runs"`
+	Failed int `json:"failed"`
+	// FailureRate is null without concluded runs, MedianSeconds without successful runs
+	FailureRate   *float64 `json:"failure_rate"`
+	MedianSeconds *float64 `json:"median_seconds"`
+}
+
+// newJSONComparison converts the runner comparison into its JSON representation
+func newJSONComparison(comparison *usecase.RunnerComparison, alpha float64) jsonComparison {
+	jobs := make([]jsonJobComparison, 0, len(comparison.Jobs))
+	for _, job := range comparison.Jobs {
+		doc := jsonJobComparison{
+			Repository:          job.Repository,
+			WorkflowName:        job.WorkflowName,
+			Name:                job.Name,
+			A:                   newJSONJobSample(job.A),
+			B:                   newJSONJobSample(job.B),
+			DurationSignificant: job.IsDurationSignificant(alpha),
+			FailureSignificant:  job.IsFailureSignificant(alpha),
+		}
+		if job.HasDurationP {
+			doc.DurationP = &job.DurationP
+			doc.DurationAdjustedP = &job.DurationAdjustedP
+		}
+		if job.HasFailureP {
+			doc.FailureP = &job.FailureP
+			doc.FailureAdjustedP = &job.FailureAdjustedP
+		}
+		jobs = append(jobs, doc)
+	}
+
+	return jsonComparison{
+		RunnerA:  newJSONRunner(comparison.A),
+		RunnerB:  newJSONRunner(comparison.B),
+		From:     comparison.From,
+		To:       comparison.To,
+		Alpha:    alpha,
+		Tests:    comparison.Tests,
+		Jobs:     jobs,
+		OnlyA:    comparison.OnlyA,
+		OnlyB:    comparison.OnlyB,
+		Failures: newJSONFailures(comparison.Failures),
+	}
+}
+
+// newJSONJobSample converts the runs of a job on one runner into their JSON representation
+func newJSONJobSample(sample usecase.JobSample) jsonJobSample {
+	doc := jsonJobSample{Runs: sample.Runs, Failed: sample.Failed}
+	if rate, ok := sample.FailureRate(); ok {
+		doc.FailureRate = &rate
+	}
+	if median, ok := sample.MedianDuration(); ok {
+		seconds := median.Seconds()
+		doc.MedianSeconds = &seconds
+	}
+	return doc
+}
+
+// writeComparisonReport writes both runners, a table of the jobs they both ran and a summary
+// of the significant differences
+func writeComparisonReport(w io.Writer, comparison *usecase.RunnerComparison, alpha float64, terminalWidth int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Runner A: %s\n", describeComparedRunner(comparison.A))
+	fmt.Fprintf(&b, "Runner B: %s\n", describeComparedRunner(comparison.B))
+	fmt.Fprintf(&b, "Window:   %s → %s (%s)\n", formatTime(&comparison.From), formatTime(&comparison.To), formatDuration(comparison.To.Sub(comparison.From)))
+	b.WriteString("\n")
+
+	if len(comparison.Jobs) == 0 {
+		b.WriteString("No jobs ran on both runners.\n")
+	} else {
+		columns := getComparisonColumnWidths(terminalWidth)
+		header := make(table.Row, len(columns))
+		for i, col := range columns {
+			header[i] = col.Title
+		}
+		writePlainRow(&b, columns, header)
+
+		showRepository := spansRepositories(comparison.Jobs)
+		for _, job := range comparison.Jobs {
+			writePlainRow(&b, columns, buildComparisonRow(job, alpha, showRepository))
+		}
+		fmt.Fprintf(&b, "\n* p < %g. p-values are adjusted for all %d tests (Holm-Bonferroni). Durations compare successful runs (Mann-Whitney U test), failure rates compare concluded runs (Fisher's exact test).\n", alpha, comparison.Tests)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(renderComparisonSummary(comparison, alpha))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// describeComparedRunner describes a runner in the comparison header
+func describeComparedRunner(runner *entity.Runner) string {
+	return fmt.Sprintf("%s (%s, %s)", runner.Name, runner.OS, runner.Status)
+}
+
+// spansRepositories reports whether the compared jobs come from more than one repository
+func spansRepositories(jobs []*usecase.JobComparison) bool {
+	for _, job := range jobs {
+		if job.Repository != jobs[0].Repository {
+			return true
+		}
+	}
+	return false
+}
+
+// buildComparisonRow converts a job comparison to a table row
+func buildComparisonRow(job *usecase.JobComparison, alpha float64, showRepository bool) table.Row {
+	name := job.WorkflowName + " / " + job.Name
+	if showRepository {
+		name = job.Repository + ": " + name
+	}
+
+	medianA, hasA := job.A.MedianDuration()
+	medianB, hasB := job.B.MedianDuration()
+	failureA, hasFailureA := job.A.FailureRate()
+	failureB, hasFailureB := job.B.FailureRate()
+
+	return table.Row{
+		name,
+		fmt.Sprintf("%d/%d", job.A.Runs, job.B.Runs),
+		formatOptionalDuration(medianA, hasA),
+		formatOptionalDuration(medianB, hasB),
+		formatDurationChange(medianA, medianB, hasA && hasB),
+		formatPValue(job.DurationAdjustedP, job.HasDurationP, alpha),
+		formatRate(failureA, hasFailureA),
+		formatRate(failureB, hasFailureB),
+		formatPValue(job.FailureAdjustedP, job.HasFailureP, alpha),
+	}
+}
+
+// renderComparisonSummary counts the jobs on which runner B is significantly faster, slower,
+// more or less reliable than runner A
+func renderComparisonSummary(comparison *usecase.RunnerComparison, alpha float64) string {
+	var faster, slower, moreReliable, lessReliable int
+	for _, job := range comparison.Jobs {
+		if job.IsDurationSignificant(alpha) {
+			medianA, _ := job.A.MedianDuration()
+			medianB, _ := job.B.MedianDuration()
+			if medianB < medianA {
+				faster++
+			} else if medianB > medianA {
+				slower++
+			}
+		}
+		if job.IsFailureSignificant(alpha) {
+			failureA, _ := job.A.FailureRate()
+			failureB, _ := job.B.FailureRate()
+			if failureB < failureA {
+				moreReliable++
+			} else if failureB > failureA {
+				lessReliable++
+			}
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Compared %d jobs that ran on both runners (%d only on A, %d only on B) with %d significance tests.\n",
+		len(comparison.Jobs), comparison.OnlyA, comparison.OnlyB, comparison.Tests)
+	fmt.Fprintf(&b, "Runner B is significantly faster on %d and slower on %d jobs.\n", faster, slower)
+	fmt.Fprintf(&b, "Runner B fails significantly less often on %d and more often on %d jobs.\n", moreReliable, lessReliable)
+	return b.String()
+}
+
+// formatOptionalDuration formats a duration, or "-" when it is not available
+func formatOptionalDuration(d time.Duration, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return formatDuration(d)
+}
+
+// formatDurationChange formats the relative change from duration a to duration b
+func formatDurationChange(a, b time.Duration, ok bool) string {
+	if !ok || a <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.0f%%", (float64(b)/float64(a)-1)*100)
+}
+
+// formatPValue formats a p-value, marking it when it is below alpha
+func formatPValue(p float64, ok bool, alpha float64) string {
+	if !ok {
+		return "-"
+	}
+	formatted := fmt.Sprintf("%.3f", p)
+	if p < 0.001 {
+		formatted = "<0.001"
+	}
+	if p < alpha {
+		formatted += "*"
+	}
+	return formatted
+}
+
+// getComparisonColumnWidths sizes the comparison table to the terminal width
+func getComparisonColumnWidths(terminalWidth int) []table.Column {
+	fixed := compareRunsWidth + 2*compareMedianWidth + compareChangeWidth + 2*comparePWidth + 2*compareFailWidth
+	// One space separates each of the 9 columns
+	jobWidth := max(terminalWidth-fixed-8, minCompareJobWidth)
+
+	return []table.Column{
+		{Title: "Job", Width: jobWidth},
+		{Title: "Runs A/B", Width: compareRunsWidth},
+		{Title: "Median A", Width: compareMedianWidth},
+		{Title: "Median B", Width: compareMedianWidth},
+		{Title: "Change", Width: compareChangeWidth},
+		{Title: "p", Width: comparePWidth},
+		{Title: "Fail A", Width: compareFailWidth},
+		{Title: "Fail B", Width: compareFailWidth},
+		{Title: "p", Width: comparePWidth},
+	}
+}
@@ -0,0 +1,164 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+func TestWriteComparisonReport(t *testing.T) {
+	from := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+	report := &usecase.RunnerComparison{
+		A:    &entity.Runner{ID: 1, Name: "old", OS: "linux", Status: "online"},
+		B:    &entity.Runner{ID: 2, Name: "new", OS: "linux", Status: "online"},
+		From: from,
+		To:   from.Add(7 * 24 * time.Hour),
+		Jobs: []*usecase.JobComparison{
+			{
+				Repository:   "owner/repo",
+				WorkflowName: "CI",
+				Name:         "build",
+				A:            usecase.JobSample{Runs: 10, Failed: 1, Durations: []time.Duration{10 * time.Minute, 12 * time.Minute}},
+				B:            usecase.JobSample{Runs: 12, Durations: []time.Duration{6 * time.Minute, 5 * time.Minute}},
+				DurationP:    0.0004,
+				HasDurationP: true,
+				FailureP:     0.45,
+				HasFailureP:  true,
+				// Adjusted for the 3 tests below
+				DurationAdjustedP: 0.0012,
+				FailureAdjustedP:  0.9,
+			},
+			{
+				Repository:       "owner/repo",
+				WorkflowName:     "CI",
+				Name:             "lint",
+				A:                usecase.JobSample{Runs: 1, Failed: 1},
+				B:                usecase.JobSample{Runs: 1, Durations: []time.Duration{time.Minute}},
+				FailureP:         1,
+				HasFailureP:      true,
+				FailureAdjustedP: 1,
+			},
+		},
+		OnlyA: 2,
+		Tests: 3,
+	}
+
+	var buf bytes.Buffer
+	if err := writeComparisonReport(&buf, report, 0.05, 100); err != nil {
+		t.Fatalf("writeComparisonReport error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 13 {
+		t.Fatalf("expected the header, 2 rows, the footnote and the summary, got:\n%s", buf.String())
+	}
+
+	if lines[0] != "Runner A: old (linux, online)" || lines[1] != "Runner B: new (linux, online)" {
+		t.Errorf("unexpected runners: %q, %q", lines[0], lines[1])
+	}
+	if fields := strings.Fields(lines[5]); strings.Join(fields, " ") != "CI / build 10/12 10m 0s 5m 0s -50% 0.001* 10.0% 0.0% 0.900" {
+		t.Errorf("unexpected build row: %q", lines[5])
+	}
+	if fields := strings.Fields(lines[6]); strings.Join(fields, " ") != "CI / lint 1/1 - 1m 0s - - 100.0% 0.0% 1.000" {
+		t.Errorf("unexpected lint row: %q", lines[6])
+	}
+	if !strings.HasPrefix(lines[8], "* p < 0.05. p-values are adjusted for all 3 tests (Holm-Bonferroni).") {
+		t.Errorf("unexpected footnote: %q", lines[8])
+	}
+	expected := []string{
+		"Compared 2 jobs that ran on both runners (2 only on A, 0 only on B) with 3 significance tests.",
+		"Runner B is significantly faster on 1 and slower on 0 jobs.",
+		"Runner B fails significantly less often on 0 and more often on 0 jobs.",
+	}
+	for i := range expected {
+		if lines[10+i] != expected[i] {
+			t.Errorf("line %d = %q, want %q", 10+i, lines[10+i], expected[i])
+		}
+	}
+}
+
+func TestWriteComparisonReport_NoCommonJobs(t *testing.T) {
+	from := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+	report := &usecase.RunnerComparison{
+		A:     &entity.Runner{ID: 1, Name: "old", OS: "linux", Status: "online"},
+		B:     &entity.Runner{ID: 2, Name: "new", OS: "linux", Status: "online"},
+		From:  from,
+		To:    from.Add(7 * 24 * time.Hour),
+		OnlyA: 2,
+	}
+
+	var buf bytes.Buffer
+	if err := writeComparisonReport(&buf, report, 0.05, 100); err != nil {
+		t.Fatalf("writeComparisonReport error: %v", err)
+	}
+	if lines := strings.Split(buf.String(), "\n"); lines[4] != "No jobs ran on both runners." {
+		t.Errorf("expected a notice without common jobs:\n%s", buf.String())
+	}
+}
+
+func TestNewJSONComparison(t *testing.T) {
+	report := &usecase.RunnerComparison{
+		A: &entity.Runner{ID: 1, Name: "old"},
+		B: &entity.Runner{ID: 2, Name: "new"},
+		Jobs: []*usecase.JobComparison{
+			{
+				WorkflowName:      "CI",
+				Name:              "build",
+				A:                 usecase.JobSample{Runs: 2, Durations: []time.Duration{10 * time.Minute, 10 * time.Minute}},
+				B:                 usecase.JobSample{Runs: 2, Durations: []time.Duration{5 * time.Minute, 5 * time.Minute}},
+				DurationP:         0.0004,
+				HasDurationP:      true,
+				DurationAdjustedP: 0.0012,
+			},
+			{
+				WorkflowName:     "CI",
+				Name:             "lint",
+				A:                usecase.JobSample{Runs: 1, Failed: 1},
+				B:                usecase.JobSample{Runs: 1, Durations: []time.Duration{time.Minute}},
+				FailureP:         1,
+				HasFailureP:      true,
+				FailureAdjustedP: 1,
+			},
+		},
+		OnlyA: 2,
+		Tests: 3,
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, newJSONComparison(report, 0.05)); err != nil {
+		t.Fatalf("writeJSON error: %v", err)
+	}
+
+	var decoded struct {
+		RunnerA struct {
+			Name string `json:"name"`
+		} `json:"runner_a"`
+		Jobs []struct {
+			A struct {
+				MedianSeconds *float64 `json:"median_seconds"`
+			} `json:"a"`
+			DurationP           *float64 `json:"duration_p_value"`
+			DurationAdjustedP   *float64 `json:"duration_p_value_adjusted"`
+			DurationSignificant bool     `json:"duration_significant"`
+		} `json:"jobs"`
+		Tests int `json:"tests"`
+		OnlyA int `json:"only_a"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if decoded.RunnerA.Name != "old" || decoded.OnlyA != 2 || decoded.Tests != 3 || len(decoded.Jobs) != 2 {
+		t.Fatalf("unexpected document: %s", buf.String())
+	}
+	if build := decoded.Jobs[0]; !build.DurationSignificant || build.DurationAdjustedP == nil || *build.DurationAdjustedP != 0.0012 || build.A.MedianSeconds == nil || *build.A.MedianSeconds != 600 {
+		t.Errorf("unexpected build comparison: %s", buf.String())
+	}
+	if lint := decoded.Jobs[1]; lint.DurationP != nil || lint.DurationAdjustedP != nil || lint.A.MedianSeconds != nil {
+		t.Errorf("expected null duration fields for lint: %s", buf.String())
+	}
+}
@@ -3,27 +3,86 @@ package presentation
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cli/go-gh/v2/pkg/term"
 )
 
+// Output formats supported by the controller
+const (
+	FormatTUI  = ""
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatTSV  = "tsv"
+
+	// formatPlain is used in place of FormatTUI when stdout is not a terminal
+	formatPlain = "plain"
+)
+
+// Options configures how the controller presents runner job history
+type Options struct {
+	// Format selects a non-interactive output format; FormatTUI launches the interactive UI
+	Format string
+	// Columns selects the columns written by FormatCSV and FormatTSV (defaults to the table columns,
+	// with durations in seconds)
+	Columns []string
+	// JQ filters FormatJSON output with a jq expression
+	JQ string
+	// Template renders FormatJSON output with a Go template
+	Template string
+	// Out is where non-interactive output is written (defaults to os.Stdout)
+	Out io.Writer
+	// ErrOut is where warnings are written in non-interactive modes (defaults to os.Stderr)
+	ErrOut io.Writer
+	// Watch keeps the interactive UI open and re-fetches the history at this interval;
+	// 0 fetches it once
+	Watch time.Duration
+}
+
 // Controller handles the presentation logic and coordinates between model and view
 type Controller struct {
 	runnerLogger *usecase.RunnerLogger
+	opts         Options
 }
 
 // NewController creates a new Controller with the given usecase
-func NewController(runnerLogger *usecase.RunnerLogger) *Controller {
+func NewController(runnerLogger *usecase.RunnerLogger, opts Options) *Controller {
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	if opts.ErrOut == nil {
+		opts.ErrOut = os.Stderr
+	}
 	return &Controller{
 		runnerLogger: runnerLogger,
+		opts:         opts,
 	}
 }
 
-// Run fetches runner job history and displays the interactive UI
-func (c *Controller) Run(ctx context.Context, runnerName string, maxCount int) error {
+// Run fetches runner job history and displays it
+// from is the start of the time window, which the timeline and heatmap of the interactive UI cover.
+func (c *Controller) Run(ctx context.Context, selector usecase.RunnerSelector, historyOpts usecase.HistoryOptions, from time.Time) error {
+	format := c.opts.Format
+	if format == FormatTUI && !term.FromEnv().IsTerminalOutput() {
+		format = formatPlain
+	}
+	if format != FormatTUI {
+		if c.opts.Watch > 0 {
+			return fmt.Errorf("watch mode requires the interactive UI on a terminal")
+		}
+		return c.runNonInteractive(ctx, selector, historyOpts, format)
+	}
+
+	// Fetching is cancelled when the user quits, even if it is still in progress
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// Create model in loading state
-	m := newLoadingModel(c.runnerLogger, runnerName, maxCount)
+	m := newLoadingModel(ctx, cancel, c.runnerLogger, selector, historyOpts, from, c.opts.Watch)
 
 	// Run TUI
 	p := tea.NewProgram(m)
@@ -40,11 +99,82 @@ func (c *Controller) Run(ctx context.Context, runnerName string, maxCount int) e
 	return nil
 }
 
+// runNonInteractive fetches the history up front and writes it without the TUI
+// If some workflow runs could not be fetched, the output is still written, followed by
+// a summary on ErrOut and an error so that the command exits with a non-zero status.
+func (c *Controller) runNonInteractive(ctx context.Context, selector usecase.RunnerSelector, historyOpts usecase.HistoryOptions, format string) error {
+	var columns []jobColumn
+	switch format {
+	case FormatJSON, formatPlain:
+	case FormatCSV, FormatTSV:
+		var err error
+		columns, err = selectColumns(c.opts.Columns)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported output format %q (expected csv or tsv)", format)
+	}
+
+	history, err := c.runnerLogger.FetchRunnerJobHistory(ctx, selector, historyOpts)
+	if err != nil {
+		return err
+	}
+
+	if err := c.writeHistory(history, format, columns); err != nil {
+		return err
+	}
+
+	if history.IsPartial() {
+		writeFailureSummary(c.opts.ErrOut, history.Failures)
+		return fmt.Errorf("job history is incomplete: %s", describeFailures(history.Failures))
+	}
+	return nil
+}
+
+// writeHistory writes the history to Out in the given non-interactive format
+func (c *Controller) writeHistory(history *usecase.RunnerJobHistory, format string, columns []jobColumn) error {
+	switch format {
+	case formatPlain:
+		return writePlainTable(c.opts.Out, history, terminalWidth())
+	case FormatCSV:
+		return writeDelimited(c.opts.Out, history, columns, ',')
+	case FormatTSV:
+		return writeDelimited(c.opts.Out, history, columns, '\t')
+	}
+
+	return writeDocument(c.opts, newJSONHistory(history))
+}
+
+// writeDocument writes a JSON document to Out, filtered by the --jq or --template options if set
+func writeDocument(opts Options, doc interface{}) error {
+	if opts.JQ != "" {
+		return writeJQ(opts.Out, doc, opts.JQ)
+	}
+	if opts.Template != "" {
+		return writeTemplate(opts.Out, doc, opts.Template)
+	}
+	return writeJSON(opts.Out, doc)
+}
+
 // newLoadingModel creates a model in loading state that will fetch data
-func newLoadingModel(runnerLogger *usecase.RunnerLogger, runnerName string, maxCount int) *Model {
+func newLoadingModel(ctx context.Context, cancel context.CancelFunc, runnerLogger *usecase.RunnerLogger, selector usecase.RunnerSelector, historyOpts usecase.HistoryOptions, from time.Time, watch time.Duration) *Model {
 	m := NewModel(nil) // nil history means loading
+	m.ctx = ctx
+	m.cancel = cancel
 	m.runnerLogger = runnerLogger
-	m.runnerName = runnerName
-	m.maxCount = maxCount
+	m.selector = selector
+	m.historyOpts = historyOpts
+	m.from = from
+	m.watch = watch
 	return m
 }
+
+// terminalWidth returns the configured terminal width, falling back to the default when unknown
+func terminalWidth() int {
+	width, _, err := term.FromEnv().Size()
+	if err != nil || width <= 0 {
+		return defaultTerminalWidth
+	}
+	return width
+}
@@ -0,0 +1,60 @@
+package presentation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	testhelpers "github.com/VeyronSakai/gh-runner-log/test"
+)
+
+func TestController_Run_PartialHistoryInNonInteractiveMode(t *testing.T) {
+	runner := &entity.Runner{ID: 1, Name: "runner-a"}
+	runnerID := int64(1)
+	jobRepo := &testhelpers.StubJobRepository{
+		Jobs: []*entity.Job{{ID: 1, Name: "build", RunnerID: &runnerID}},
+		Failures: []repository.RunFailure{
+			{RunID: 42, Repository: "owner/repo", Err: errors.New("HTTP 502")},
+		},
+	}
+	runnerLogger := usecase.NewRunnerLogger(jobRepo, &testhelpers.StubRunnerRepository{Runner: runner})
+
+	var out, errOut bytes.Buffer
+	controller := NewController(runnerLogger, Options{Format: FormatCSV, Columns: []string{"job_id"}, Out: &out, ErrOut: &errOut})
+
+	err := controller.Run(context.Background(), usecase.RunnerSelector{Names: []string{"runner-a"}}, usecase.HistoryOptions{Limit: 10}, time.Time{})
+	if err == nil {
+		t.Fatal("expected an error for partial history")
+	}
+
+	if out.String() != "Job ID\n1\n" {
+		t.Errorf("expected available jobs to be written, got %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "owner/repo run 42: HTTP 502") {
+		t.Errorf("expected failure summary on stderr, got %q", errOut.String())
+	}
+}
+
+func TestController_Run_CompleteHistoryInNonInteractiveMode(t *testing.T) {
+	runner := &entity.Runner{ID: 1, Name: "runner-a"}
+	runnerLogger := usecase.NewRunnerLogger(&testhelpers.StubJobRepository{}, &testhelpers.StubRunnerRepository{Runner: runner})
+
+	var out, errOut bytes.Buffer
+	controller := NewController(runnerLogger, Options{Format: FormatJSON, Out: &out, ErrOut: &errOut})
+
+	if err := controller.Run(context.Background(), usecase.RunnerSelector{Names: []string{"runner-a"}}, usecase.HistoryOptions{Limit: 10}, time.Time{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("expected no warnings, got %q", errOut.String())
+	}
+	if strings.Contains(out.String(), "failures") {
+		t.Errorf("expected failures to be omitted for complete history:\n%s", out.String())
+	}
+}
@@ -0,0 +1,138 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/mattn/go-runewidth"
+)
+
+// Detail pane layout constants
+const (
+	// detailPaneSteps is the number of steps listed in the detail pane
+	detailPaneSteps = 8
+	// detailPaneHeight is the number of lines of the detail pane: a blank line, the title and the steps
+	detailPaneHeight    = detailPaneSteps + 2
+	stepNumberWidth     = 3
+	stepConclusionWidth = 12
+	stepDurationWidth   = 16
+	minStepNameWidth    = 20
+)
+
+// renderJobDetail renders the steps of the job with their conclusion, duration and share of the
+// job's duration, padded to detailPaneHeight lines so that the table does not move with the cursor
+// Jobs with more steps than fit show the steps around the first failed or running step, or
+// around the slowest step.
+func renderJobDetail(job *entity.Job, width int, now time.Time) string {
+	var b strings.Builder
+	b.WriteString("\n")
+	if job == nil {
+		b.WriteString(statsTitleStyle.Render("No job selected") + "\n")
+		return padLines(b.String(), detailPaneHeight)
+	}
+
+	title := fmt.Sprintf("Steps of %s / %s (attempt %d on %s)", job.WorkflowName, job.Name, job.RunAttempt, formatRunnerName(job))
+	if len(job.Steps) == 0 {
+		b.WriteString(statsTitleStyle.Render(title) + "\n")
+		b.WriteString("  No steps reported for this job.\n")
+		return padLines(b.String(), detailPaneHeight)
+	}
+
+	start := stepWindowStart(job.Steps, detailPaneSteps)
+	end := min(start+detailPaneSteps, len(job.Steps))
+	if len(job.Steps) > detailPaneSteps {
+		title += fmt.Sprintf(" – steps %d-%d of %d", start+1, end, len(job.Steps))
+	}
+	b.WriteString(statsTitleStyle.Render(runewidth.Truncate(title, max(width, minStepNameWidth), "…")) + "\n")
+
+	jobDuration := job.GetExecutionDuration()
+	if job.StartedAt != nil && job.CompletedAt == nil {
+		jobDuration = now.Sub(*job.StartedAt)
+	}
+	nameWidth := max(width-stepNumberWidth-stepConclusionWidth-stepDurationWidth-utilizationBarWidth-8, minStepNameWidth)
+	for _, step := range job.Steps[start:end] {
+		d := stepDuration(step, now)
+		share := 0.0
+		if jobDuration > 0 {
+			share = float64(d) / float64(jobDuration) * 100
+		}
+		line := fmt.Sprintf("  %s  %s  %s  %s  %s",
+			runewidth.FillLeft(fmt.Sprint(step.Number), stepNumberWidth),
+			runewidth.FillRight(runewidth.Truncate(step.Name, nameWidth, "…"), nameWidth),
+			runewidth.FillRight(formatStepOutcome(step), stepConclusionWidth),
+			runewidth.FillRight(formatStepDuration(step, now), stepDurationWidth),
+			renderUtilizationBar(share),
+		)
+		// Colour the steps that need attention
+		if !step.IsCompleted() || step.Conclusion != entity.ConclusionSuccess {
+			line = outcomeStyle(step.IsCompleted(), step.Conclusion).Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return padLines(b.String(), detailPaneHeight)
+}
+
+// stepWindowStart returns the first of limit consecutive steps to show, centred on the first
+// failed or running step, or on the slowest step when every step succeeded
+func stepWindowStart(steps []entity.Step, limit int) int {
+	if len(steps) <= limit {
+		return 0
+	}
+
+	focus := -1
+	for i, step := range steps {
+		if !step.IsCompleted() || step.Conclusion == entity.ConclusionFailure || step.Conclusion == entity.ConclusionTimedOut {
+			focus = i
+			break
+		}
+	}
+	if focus < 0 {
+		focus = 0
+		for i, step := range steps {
+			if step.GetExecutionDuration() > steps[focus].GetExecutionDuration() {
+				focus = i
+			}
+		}
+	}
+	return min(max(focus-limit/2, 0), len(steps)-limit)
+}
+
+// stepDuration returns how long the step ran; steps still running count until now
+func stepDuration(step entity.Step, now time.Time) time.Duration {
+	if step.StartedAt == nil {
+		return 0
+	}
+	if step.CompletedAt == nil {
+		return now.Sub(*step.StartedAt)
+	}
+	return step.GetExecutionDuration()
+}
+
+// formatStepOutcome returns the conclusion of a finished step, or its status otherwise
+func formatStepOutcome(step entity.Step) string {
+	if step.IsCompleted() && step.Conclusion != "" {
+		return step.Conclusion
+	}
+	return step.Status
+}
+
+// formatStepDuration formats how long the step ran, or "-" if it has not started
+func formatStepDuration(step entity.Step, now time.Time) string {
+	if step.StartedAt == nil {
+		return "-"
+	}
+	if step.CompletedAt == nil {
+		return formatDuration(stepDuration(step, now)) + " (running)"
+	}
+	return formatDuration(step.GetExecutionDuration())
+}
+
+// padLines appends empty lines until s has n lines
+func padLines(s string, n int) string {
+	if lines := strings.Count(s, "\n"); lines < n {
+		s += strings.Repeat("\n", n-lines)
+	}
+	return s
+}
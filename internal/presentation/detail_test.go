@@ -0,0 +1,139 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestSteps creates completed steps that ran back to back for the given durations
+func newTestSteps(start time.Time, conclusions []string, durations []time.Duration) []entity.Step {
+	steps := make([]entity.Step, len(durations))
+	for i, d := range durations {
+		started, completed := start, start.Add(d)
+		steps[i] = entity.Step{
+			Number:      i + 1,
+			Name:        fmt.Sprintf("step %d", i+1),
+			Status:      entity.StatusCompleted,
+			Conclusion:  conclusions[i],
+			StartedAt:   &started,
+			CompletedAt: &completed,
+		}
+		start = completed
+	}
+	return steps
+}
+
+func TestRenderJobDetail(t *testing.T) {
+	start := time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC)
+	secondStarted := start.Add(time.Minute)
+	runner := "runner-a"
+	job := &entity.Job{
+		Name:         "build",
+		WorkflowName: "CI",
+		RunAttempt:   2,
+		RunnerName:   &runner,
+		Status:       entity.StatusInProgress,
+		StartedAt:    &start,
+		Steps: []entity.Step{
+			newTestSteps(start, []string{"success"}, []time.Duration{time.Minute})[0],
+			{Number: 2, Name: "Run tests", Status: entity.StatusInProgress, StartedAt: &secondStarted},
+			{Number: 3, Name: "Upload", Status: entity.StatusQueued},
+		},
+	}
+	now := start.Add(4 * time.Minute)
+
+	output := renderJobDetail(job, 100, now)
+
+	if lines := strings.Count(output, "\n"); lines != detailPaneHeight {
+		t.Errorf("expected %d lines, got %d:\n%s", detailPaneHeight, lines, output)
+	}
+	for _, expected := range []string{
+		"Steps of CI / build (attempt 2 on runner-a)",
+		"step 1",
+		"success",
+		"1m 0s ",
+		// A quarter of the job so far
+		"█████░░░░░░░░░░░░░░░",
+		"Run tests",
+		"3m 0s (running)",
+		"queued",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, output)
+		}
+	}
+}
+
+func TestRenderJobDetail_NoSteps(t *testing.T) {
+	output := renderJobDetail(&entity.Job{Name: "build", WorkflowName: "CI"}, 100, time.Now())
+
+	if !strings.Contains(output, "No steps reported for this job.") {
+		t.Errorf("expected a placeholder without steps:\n%s", output)
+	}
+	if lines := strings.Count(output, "\n"); lines != detailPaneHeight {
+		t.Errorf("expected %d lines, got %d", detailPaneHeight, lines)
+	}
+}
+
+func TestStepWindowStart(t *testing.T) {
+	start := time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC)
+	success := func(n int) []string {
+		conclusions := make([]string, n)
+		for i := range conclusions {
+			conclusions[i] = entity.ConclusionSuccess
+		}
+		return conclusions
+	}
+	durations := func(n int) []time.Duration {
+		d := make([]time.Duration, n)
+		for i := range d {
+			d[i] = time.Second
+		}
+		return d
+	}
+
+	// Every step fits
+	if got := stepWindowStart(newTestSteps(start, success(4), durations(4)), 8); got != 0 {
+		t.Errorf("expected 0 when every step fits, got %d", got)
+	}
+
+	// Centred on the slowest step
+	d := durations(20)
+	d[12] = time.Minute
+	if got := stepWindowStart(newTestSteps(start, success(20), d), 8); got != 8 {
+		t.Errorf("expected the window around the slowest step to start at 8, got %d", got)
+	}
+
+	// The first failed step wins over the slowest step, and the window stays within the steps
+	conclusions := success(20)
+	conclusions[18] = "failure"
+	if got := stepWindowStart(newTestSteps(start, conclusions, d), 8); got != 12 {
+		t.Errorf("expected the window to end with the last step, got %d", got)
+	}
+}
+
+func TestModel_DetailPaneKey(t *testing.T) {
+	jobs := make([]*entity.Job, 30)
+	for i := range jobs {
+		jobs[i] = &entity.Job{ID: int64(i), Name: "build", Status: entity.StatusQueued}
+	}
+	m := NewModel(&usecase.RunnerJobHistory{Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}}, Jobs: jobs})
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if !m.showDetail {
+		t.Error("expected i to show the detail pane")
+	}
+
+	// d still moves the table down by half a page
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if m.table.Cursor() == 0 || !m.showDetail {
+		t.Errorf("expected d to move the cursor and keep the detail pane, got cursor %d", m.table.Cursor())
+	}
+}
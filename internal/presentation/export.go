@@ -0,0 +1,36 @@
+package presentation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+// writeDelimited writes the history as delimiter-separated values with a header row
+func writeDelimited(w io.Writer, history *usecase.RunnerJobHistory, columns []jobColumn, delimiter rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.title
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, job := range history.Jobs {
+		record := make([]string, len(columns))
+		for i, col := range columns {
+			record[i] = col.exportValue(job)
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write job %d: %w", job.ID, err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
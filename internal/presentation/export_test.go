@@ -0,0 +1,109 @@
+package presentation
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+func TestSelectColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        []string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "defaults are the interactive table columns with durations in seconds",
+			keys:     nil,
+			expected: []string{"Workflow", "Job", "Attempt", "Status", "Conclusion", "Queued Seconds", "Started At", "Duration Seconds"},
+		},
+		{
+			name:     "custom selection keeps order",
+			keys:     []string{"url", "job_id", " repository "},
+			expected: []string{"URL", "Job ID", "Repository"},
+		},
+		{
+			name:        "unknown column",
+			keys:        []string{"job", "bogus"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectColumns(tt.keys)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("selectColumns(%v) expected error, got nil", tt.keys)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectColumns(%v) unexpected error: %v", tt.keys, err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %d columns, got %d", len(tt.expected), len(got))
+			}
+			for i, title := range tt.expected {
+				if got[i].title != title {
+					t.Errorf("column %d = %q, want %q", i, got[i].title, title)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteDelimited(t *testing.T) {
+	created := time.Date(2025, 11, 16, 0, 59, 30, 0, time.UTC)
+	started := time.Date(2025, 11, 16, 1, 0, 0, 0, time.UTC)
+	completed := started.Add(4 * time.Minute)
+	history := &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}},
+		Jobs: []*entity.Job{
+			{ID: 1, RunID: 1001, Name: "build, test", Repository: "owner/repo", CreatedAt: &created, StartedAt: &started, CompletedAt: &completed},
+			{ID: 2, RunID: 1002, Name: "deploy", Repository: "owner/repo"},
+		},
+	}
+
+	columns, err := selectColumns([]string{"job_id", "run_id", "job", "queued_seconds", "started_at", "duration_seconds"})
+	if err != nil {
+		t.Fatalf("selectColumns error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		delimiter rune
+		expected  string
+	}{
+		{
+			name:      "csv quotes fields containing the delimiter",
+			delimiter: ',',
+			expected: "Job ID,Run ID,Job,Queued Seconds,Started At,Duration Seconds\n" +
+				"1,1001,\"build, test\",30,2025-11-16T01:00:00Z,240\n" +
+				"2,1002,deploy,,,\n",
+		},
+		{
+			name:      "tsv",
+			delimiter: '\t',
+			expected: "Job ID\tRun ID\tJob\tQueued Seconds\tStarted At\tDuration Seconds\n" +
+				"1\t1001\tbuild, test\t30\t2025-11-16T01:00:00Z\t240\n" +
+				"2\t1002\tdeploy\t\t\t\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeDelimited(&buf, history, columns, tt.delimiter); err != nil {
+				t.Fatalf("writeDelimited error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("unexpected output:\n%q\nwant:\n%q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package presentation
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/cli/go-gh/v2/pkg/template"
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+// writeJQ filters a JSON document with a jq expression, like `gh api --jq`
+func writeJQ(w io.Writer, doc interface{}, expr string) error {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, doc); err != nil {
+		return err
+	}
+
+	t := term.FromEnv()
+	if err := jq.EvaluateFormatted(&buf, w, expr, "  ", t.IsColorEnabled()); err != nil {
+		return fmt.Errorf("failed to evaluate --jq expression: %w", err)
+	}
+	return nil
+}
+
+// writeTemplate renders a JSON document with a Go template, like `gh api --template`
+func writeTemplate(w io.Writer, doc interface{}, tmpl string) error {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, doc); err != nil {
+		return err
+	}
+
+	renderer := template.New(w, terminalWidth(), term.FromEnv().IsColorEnabled()).Funcs(map[string]interface{}{
+		"duration": durationFunc,
+	})
+	if err := renderer.Parse(tmpl); err != nil {
+		return fmt.Errorf("failed to parse --template: %w", err)
+	}
+	if err := renderer.Execute(&buf); err != nil {
+		return fmt.Errorf("failed to execute --template: %w", err)
+	}
+	return renderer.Flush()
+}
+
+// durationFunc formats a number of seconds (such as duration_seconds) the way the table does
+func durationFunc(seconds interface{}) (string, error) {
+	switch v := seconds.(type) {
+	case float64:
+		return formatDuration(time.Duration(v * float64(time.Second))), nil
+	case int:
+		return formatDuration(time.Duration(v) * time.Second), nil
+	case int64:
+		return formatDuration(time.Duration(v) * time.Second), nil
+	case nil:
+		return "-", nil
+	default:
+		return "", fmt.Errorf("cannot format %v (%T) as a duration", seconds, seconds)
+	}
+}
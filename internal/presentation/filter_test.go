@@ -0,0 +1,53 @@
+package presentation
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+func newFilterTestHistory() *usecase.RunnerJobHistory {
+	started := time.Date(2025, 11, 16, 1, 0, 0, 0, time.UTC)
+	completed := started.Add(90 * time.Second)
+	return &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}},
+		Jobs: []*entity.Job{
+			{ID: 1, Name: "build", Conclusion: "success", StartedAt: &started, CompletedAt: &completed},
+			{ID: 2, Name: "test", Conclusion: "failure", StartedAt: &started, CompletedAt: &completed},
+		},
+	}
+}
+
+func TestWriteJQ(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeJQ(&buf, newJSONHistory(newFilterTestHistory()), `.jobs[] | select(.conclusion == "failure") | .name`)
+	if err != nil {
+		t.Fatalf("writeJQ error: %v", err)
+	}
+	if buf.String() != "test\n" {
+		t.Errorf("writeJQ output = %q, want %q", buf.String(), "test\n")
+	}
+}
+
+func TestWriteJQ_InvalidExpression(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeJQ(&buf, newJSONHistory(newFilterTestHistory()), `.jobs[`); err == nil {
+		t.Fatal("expected error for invalid jq expression")
+	}
+}
+
+func TestWriteTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	tmpl := `{{.runner.name}}{{range .jobs}} {{.name}}={{duration .duration_seconds}}{{end}}`
+	if err := writeTemplate(&buf, newJSONHistory(newFilterTestHistory()), tmpl); err != nil {
+		t.Fatalf("writeTemplate error: %v", err)
+	}
+
+	expected := "runner-a build=1m 30s test=1m 30s"
+	if buf.String() != expected {
+		t.Errorf("writeTemplate output = %q, want %q", buf.String(), expected)
+	}
+}
@@ -0,0 +1,205 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/mattn/go-runewidth"
+)
+
+// maxFlakyAttemptsShown is the number of flaky attempts listed under each flaky job
+const maxFlakyAttemptsShown = 3
+
+// RunFlaky fetches every job in scope since the given time and writes a report of flaky jobs
+// and how their failures spread over runners, or its JSON form with FormatJSON
+func (c *Controller) RunFlaky(ctx context.Context, selector usecase.RunnerSelector, historyOpts usecase.HistoryOptions, since time.Time) error {
+	switch c.opts.Format {
+	case FormatTUI, FormatJSON:
+	default:
+		return fmt.Errorf("unsupported output format %q for flaky jobs", c.opts.Format)
+	}
+
+	report, err := c.runnerLogger.FetchFlakyJobs(ctx, selector, historyOpts, since)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.Format == FormatJSON {
+		err = writeDocument(c.opts, newJSONFlaky(report))
+	} else {
+		err = writeFlakyReport(c.opts.Out, report, terminalWidth())
+	}
+	if err != nil {
+		return err
+	}
+
+	if report.History.IsPartial() {
+		writeFailureSummary(c.opts.ErrOut, report.History.Failures)
+		return fmt.Errorf("flaky job report is incomplete: %s", describeFailures(report.History.Failures))
+	}
+	return nil
+}
+
+// jsonFlaky is the JSON document written by the flaky subcommand with --json
+type jsonFlaky struct {
+	Runner          jsonRunner            `json:"runner"`
+	Runners         []*jsonRunner         `json:"runners,omitempty"`
+	RunnerPattern   string                `json:"runner_pattern,omitempty"`
+	From            time.Time             `json:"from"`
+	To              time.Time             `json:"to"`
+	Selected        jsonRunnerFlakiness   `json:"selected"`
+	Others          jsonRunnerFlakiness   `json:"others"`
+	FlakyJobs       []jsonFlakyJob        `json:"flaky_jobs"`
+	RunnerFlakiness []jsonRunnerFlakiness `json:"runner_flakiness"`
+	Failures        []jsonFailure         `json:"failures,omitempty"`
+}
+
+// jsonFlakyJob is the JSON form of usecase.FlakyJob
+type jsonFlakyJob struct {
+	Repository       string             `json:"repository"`
+	WorkflowName     string             `json:"workflow_name"`
+	Name             string             `json:"name"`
+	FailedOnSelected int                `json:"failed_on_selected"`
+	Attempts         []jsonFlakyAttempt `json:"attempts"`
+}
+
+// jsonFlakyAttempt is the JSON form of usecase.FlakyAttempt
+type jsonFlakyAttempt struct {
+	Failed    jsonJob `json:"failed"`
+	Succeeded jsonJob `json:"succeeded"`
+}
+
+// jsonRunnerFlakiness is the JSON form of usecase.RunnerFlakiness
+type jsonRunnerFlakiness struct {
+	Runner        string `json:"runner,omitempty"`
+	Selected      bool   `json:"selected"`
+	Jobs          int    `json:"jobs"`
+	FlakyFailures int    `json:"flaky_failures"`
+	// FlakyRate is null when no jobs ran
+	FlakyRate *float64 `json:"flaky_rate"`
+}
+
+// newJSONFlaky converts the flaky job report into its JSON representation
+func newJSONFlaky(report *usecase.FlakyReport) jsonFlaky {
+	history := newJSONHistory(report.History)
+
+	flakyJobs := make([]jsonFlakyJob, 0, len(report.FlakyJobs))
+	for _, job := range report.FlakyJobs {
+		attempts := make([]jsonFlakyAttempt, 0, len(job.Attempts))
+		for _, attempt := range job.Attempts {
+			attempts = append(attempts, jsonFlakyAttempt{
+				Failed:    newJSONJob(attempt.Failed),
+				Succeeded: newJSONJob(attempt.Succeeded),
+			})
+		}
+		flakyJobs = append(flakyJobs, jsonFlakyJob{
+			Repository:       job.Repository,
+			WorkflowName:     job.WorkflowName,
+			Name:             job.Name,
+			FailedOnSelected: job.FailedOnSelected,
+			Attempts:         attempts,
+		})
+	}
+
+	runners := make([]jsonRunnerFlakiness, 0, len(report.Runners))
+	for _, runner := range report.Runners {
+		runners = append(runners, newJSONRunnerFlakiness(runner))
+	}
+
+	selected := newJSONRunnerFlakiness(report.Selected)
+	selected.Selected = true
+	return jsonFlaky{
+		Runner:          history.Runner,
+		Runners:         history.Runners,
+		RunnerPattern:   history.RunnerPattern,
+		From:            report.From,
+		To:              report.To,
+		Selected:        selected,
+		Others:          newJSONRunnerFlakiness(report.Others),
+		FlakyJobs:       flakyJobs,
+		RunnerFlakiness: runners,
+		Failures:        history.Failures,
+	}
+}
+
+// newJSONRunnerFlakiness converts per-runner flaky failure counts into their JSON representation
+func newJSONRunnerFlakiness(flakiness usecase.RunnerFlakiness) jsonRunnerFlakiness {
+	doc := jsonRunnerFlakiness{
+		Runner:        flakiness.Runner,
+		Selected:      flakiness.Selected,
+		Jobs:          flakiness.Jobs,
+		FlakyFailures: flakiness.FlakyFailures,
+	}
+	if rate, ok := flakiness.FlakyRate(); ok {
+		doc.FlakyRate = &rate
+	}
+	return doc
+}
+
+// writeFlakyReport writes the runner header, the flaky failure rates of the selected and other
+// runners, the flaky jobs and the runners with the most flaky failures
+func writeFlakyReport(w io.Writer, report *usecase.FlakyReport, terminalWidth int) error {
+	var b strings.Builder
+	b.WriteString(renderHeader(report.History, terminalWidth))
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Window:           %s → %s (%s)\n", formatTime(&report.From), formatTime(&report.To), formatDuration(report.To.Sub(report.From)))
+	fmt.Fprintf(&b, "Selected runners: %s\n", formatFlakyRate(report.Selected))
+	fmt.Fprintf(&b, "Other runners:    %s\n", formatFlakyRate(report.Others))
+
+	b.WriteString("\nFlaky jobs:\n")
+	if len(report.FlakyJobs) == 0 {
+		b.WriteString("  -\n")
+	}
+	for _, job := range report.FlakyJobs {
+		fmt.Fprintf(&b, "  %s  %s / %s  %d flaky, %d failed on the selected runners\n",
+			job.Repository,
+			job.WorkflowName,
+			job.Name,
+			len(job.Attempts),
+			job.FailedOnSelected,
+		)
+		for i, attempt := range job.Attempts {
+			if i == maxFlakyAttemptsShown {
+				fmt.Fprintf(&b, "    … %d more\n", len(job.Attempts)-maxFlakyAttemptsShown)
+				break
+			}
+			fmt.Fprintf(&b, "    run %d: attempt %d %s on %s, attempt %d succeeded on %s\n",
+				attempt.Failed.RunID,
+				attempt.Failed.RunAttempt,
+				attempt.Failed.Conclusion,
+				formatRunnerName(attempt.Failed),
+				attempt.Succeeded.RunAttempt,
+				formatRunnerName(attempt.Succeeded),
+			)
+		}
+	}
+
+	b.WriteString("\nFlaky failures by runner (* selected):\n")
+	if len(report.Runners) == 0 {
+		b.WriteString("  -\n")
+	}
+	nameWidth := 0
+	for _, runner := range report.Runners {
+		nameWidth = max(nameWidth, runewidth.StringWidth(runner.Runner)+1)
+	}
+	for _, runner := range report.Runners {
+		name := runner.Runner
+		if runner.Selected {
+			name += "*"
+		}
+		fmt.Fprintf(&b, "  %s  %s\n", runewidth.FillRight(name, nameWidth), formatFlakyRate(runner))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatFlakyRate describes how many of the jobs were flaky failures
+func formatFlakyRate(flakiness usecase.RunnerFlakiness) string {
+	rate, ok := flakiness.FlakyRate()
+	return fmt.Sprintf("%s flaky (%d of %d jobs)", formatRate(rate, ok), flakiness.FlakyFailures, flakiness.Jobs)
+}
@@ -0,0 +1,149 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+func TestWriteFlakyReport(t *testing.T) {
+	runnerA, runnerB := "runner-a", "runner-b"
+	var attempts []usecase.FlakyAttempt
+	for run := int64(1); run <= 4; run++ {
+		attempts = append(attempts, usecase.FlakyAttempt{
+			Failed:    &entity.Job{ID: run * 10, RunID: run, RunAttempt: 1, Conclusion: "failure", RunnerName: &runnerA},
+			Succeeded: &entity.Job{ID: run*10 + 1, RunID: run, RunAttempt: 2, Conclusion: "success", RunnerName: &runnerB},
+		})
+	}
+	from := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+	report := &usecase.FlakyReport{
+		History: &usecase.RunnerJobHistory{
+			Runners: []*entity.Runner{{ID: 1, Name: "runner-a", Status: "online", OS: "linux"}},
+		},
+		From: from,
+		To:   from.Add(7 * 24 * time.Hour),
+		FlakyJobs: []*usecase.FlakyJob{
+			{Repository: "owner/repo", WorkflowName: "CI", Name: "test", Attempts: attempts, FailedOnSelected: 4},
+		},
+		Runners: []usecase.RunnerFlakiness{
+			{Runner: "runner-a", Selected: true, Jobs: 40, FlakyFailures: 4},
+			{Runner: "runner-b", Jobs: 0},
+		},
+		Selected: usecase.RunnerFlakiness{Jobs: 40, FlakyFailures: 4},
+		Others:   usecase.RunnerFlakiness{Jobs: 200, FlakyFailures: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := writeFlakyReport(&buf, report, defaultTerminalWidth); err != nil {
+		t.Fatalf("writeFlakyReport error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	// Everything after the runner header and the window
+	expected := []string{
+		"Selected runners: 10.0% flaky (4 of 40 jobs)",
+		"Other runners:    0.5% flaky (1 of 200 jobs)",
+		"",
+		"Flaky jobs:",
+		"  owner/repo  CI / test  4 flaky, 4 failed on the selected runners",
+		"    run 1: attempt 1 failure on runner-a, attempt 2 succeeded on runner-b",
+		"    run 2: attempt 1 failure on runner-a, attempt 2 succeeded on runner-b",
+		"    run 3: attempt 1 failure on runner-a, attempt 2 succeeded on runner-b",
+		"    … 1 more",
+		"",
+		"Flaky failures by runner (* selected):",
+		"  runner-a*  10.0% flaky (4 of 40 jobs)",
+		"  runner-b   - flaky (0 of 0 jobs)",
+	}
+	if len(lines) != 6+len(expected) {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+	for i := range expected {
+		if lines[6+i] != expected[i] {
+			t.Errorf("line %d = %q, want %q", 6+i, lines[6+i], expected[i])
+		}
+	}
+}
+
+func TestWriteFlakyReport_NoFlakyJobs(t *testing.T) {
+	report := &usecase.FlakyReport{
+		History:  &usecase.RunnerJobHistory{Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}}},
+		Selected: usecase.RunnerFlakiness{Jobs: 40},
+	}
+
+	var buf bytes.Buffer
+	if err := writeFlakyReport(&buf, report, defaultTerminalWidth); err != nil {
+		t.Fatalf("writeFlakyReport error: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if lines[9] != "Flaky jobs:" || lines[10] != "  -" {
+		t.Errorf("expected a placeholder without flaky jobs:\n%s", buf.String())
+	}
+}
+
+func TestNewJSONFlaky(t *testing.T) {
+	runnerA, runnerB := "runner-a", "runner-b"
+	report := &usecase.FlakyReport{
+		History: &usecase.RunnerJobHistory{Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}}},
+		FlakyJobs: []*usecase.FlakyJob{
+			{
+				Repository:   "owner/repo",
+				WorkflowName: "CI",
+				Name:         "test",
+				Attempts: []usecase.FlakyAttempt{{
+					Failed:    &entity.Job{ID: 10, RunID: 1, RunAttempt: 1, Conclusion: "failure", RunnerName: &runnerA},
+					Succeeded: &entity.Job{ID: 11, RunID: 1, RunAttempt: 2, Conclusion: "success", RunnerName: &runnerB},
+				}},
+				FailedOnSelected: 1,
+			},
+		},
+		Runners: []usecase.RunnerFlakiness{
+			{Runner: "runner-a", Selected: true, Jobs: 10, FlakyFailures: 1},
+			{Runner: "runner-b", Jobs: 0},
+		},
+		Selected: usecase.RunnerFlakiness{Jobs: 10, FlakyFailures: 1},
+		Others:   usecase.RunnerFlakiness{Jobs: 200},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, newJSONFlaky(report)); err != nil {
+		t.Fatalf("writeJSON error: %v", err)
+	}
+
+	var decoded struct {
+		Selected struct {
+			Selected  bool     `json:"selected"`
+			FlakyRate *float64 `json:"flaky_rate"`
+		} `json:"selected"`
+		FlakyJobs []struct {
+			Name     string `json:"name"`
+			Attempts []struct {
+				Failed struct {
+					RunnerName string `json:"runner_name"`
+				} `json:"failed"`
+			} `json:"attempts"`
+		} `json:"flaky_jobs"`
+		RunnerFlakiness []struct {
+			Runner    string   `json:"runner"`
+			FlakyRate *float64 `json:"flaky_rate"`
+		} `json:"runner_flakiness"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if !decoded.Selected.Selected || decoded.Selected.FlakyRate == nil || *decoded.Selected.FlakyRate != 0.1 {
+		t.Errorf("unexpected selected totals: %s", buf.String())
+	}
+	if len(decoded.FlakyJobs) != 1 || len(decoded.FlakyJobs[0].Attempts) != 1 || decoded.FlakyJobs[0].Attempts[0].Failed.RunnerName != "runner-a" {
+		t.Errorf("unexpected flaky jobs: %+v", decoded.FlakyJobs)
+	}
+	if len(decoded.RunnerFlakiness) != 2 || decoded.RunnerFlakiness[1].FlakyRate != nil {
+		t.Errorf("expected a null rate for a runner without jobs: %+v", decoded.RunnerFlakiness)
+	}
+}
@@ -0,0 +1,196 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/charmbracelet/bubbles/table"
+)
+
+// Fixed widths of the fleet table columns; Name and Labels share the remaining width
+const (
+	fleetStatusWidth   = 8
+	fleetBusyWidth     = 4
+	fleetOSWidth       = 7
+	fleetLastJobWidth  = 16
+	fleetJobsWidth     = 5
+	fleetSuccessWidth  = 7
+	minFleetNameWidth  = 12
+	minFleetLabelWidth = 12
+)
+
+// RunFleet fetches the summary of every runner in scope since the given time and writes it as
+// a table, or as JSON with FormatJSON
+// Like the job history, a partial summary is still written, followed by a warning and an error.
+func (c *Controller) RunFleet(ctx context.Context, fleetOpts usecase.FleetOptions, since time.Time) error {
+	switch c.opts.Format {
+	case FormatTUI, FormatJSON:
+	default:
+		return fmt.Errorf("unsupported output format %q for the runner overview", c.opts.Format)
+	}
+
+	summary, err := c.runnerLogger.FetchFleetSummary(ctx, fleetOpts, since)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.Format == FormatJSON {
+		err = writeDocument(c.opts, newJSONFleet(summary))
+	} else {
+		err = writeFleetTable(c.opts.Out, summary, terminalWidth(), time.Now())
+	}
+	if err != nil {
+		return err
+	}
+
+	if summary.IsPartial() {
+		writeFailureSummary(c.opts.ErrOut, summary.Failures)
+		return fmt.Errorf("runner overview is incomplete: %s", describeFailures(summary.Failures))
+	}
+	return nil
+}
+
+// jsonFleet is the JSON document written by the runners subcommand with --json
+type jsonFleet struct {
+	Runners  []jsonRunnerSummary `json:"runners"`
+	Failures []jsonFailure       `json:"failures,omitempty"`
+}
+
+// jsonRunnerSummary is the JSON form of usecase.RunnerSummary
+type jsonRunnerSummary struct {
+	jsonRunner
+	LastJob  *jsonJob `json:"last_job"`
+	JobCount int      `json:"job_count"`
+	// SuccessRate is null when no jobs have concluded
+	SuccessRate *float64 `json:"success_rate"`
+}
+
+// newJSONFleet converts the fleet summary into its JSON representation
+func newJSONFleet(summary *usecase.FleetSummary) jsonFleet {
+	runners := make([]jsonRunnerSummary, 0, len(summary.Runners))
+	for _, runnerSummary := range summary.Runners {
+		runner := jsonRunnerSummary{
+			jsonRunner: *newJSONRunner(runnerSummary.Runner),
+			JobCount:   runnerSummary.Stats.Total,
+		}
+		if runnerSummary.LastJob != nil {
+			lastJob := newJSONJob(runnerSummary.LastJob)
+			runner.LastJob = &lastJob
+		}
+		if rate, ok := runnerSummary.Stats.SuccessRate(); ok {
+			runner.SuccessRate = &rate
+		}
+		runners = append(runners, runner)
+	}
+
+	return jsonFleet{
+		Runners:  runners,
+		Failures: newJSONFailures(summary.Failures),
+	}
+}
+
+// writeFleetTable writes the fleet summary as a column-aligned table
+func writeFleetTable(w io.Writer, summary *usecase.FleetSummary, terminalWidth int, now time.Time) error {
+	columns := getFleetColumnWidths(terminalWidth)
+
+	var b strings.Builder
+	b.WriteString(renderFleetHeader(summary))
+	b.WriteString("\n")
+
+	header := make(table.Row, len(columns))
+	for i, col := range columns {
+		header[i] = col.Title
+	}
+	writePlainRow(&b, columns, header)
+
+	for _, runnerSummary := range summary.Runners {
+		writePlainRow(&b, columns, buildFleetRow(runnerSummary, now))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderFleetHeader counts the runners by state
+func renderFleetHeader(summary *usecase.FleetSummary) string {
+	var online, busy, idle int
+	for _, runnerSummary := range summary.Runners {
+		if runnerSummary.Runner.Status == "online" {
+			online++
+		}
+		if runnerSummary.Runner.Busy {
+			busy++
+		}
+		if runnerSummary.IsIdle() {
+			idle++
+		}
+	}
+	return fmt.Sprintf("Runners: %d (online: %d, busy: %d, no jobs in window: %d)\n",
+		len(summary.Runners), online, busy, idle)
+}
+
+// buildFleetRow converts a runner summary to a table row
+func buildFleetRow(summary *usecase.RunnerSummary, now time.Time) table.Row {
+	busy := "no"
+	if summary.Runner.Busy {
+		busy = "yes"
+	}
+
+	return table.Row{
+		summary.Runner.Name,
+		summary.Runner.Status,
+		busy,
+		summary.Runner.OS,
+		strings.Join(summary.Runner.Labels, ","),
+		formatLastJob(summary, now),
+		strconv.Itoa(summary.Stats.Total),
+		formatSuccessRate(summary),
+	}
+}
+
+// formatLastJob describes how long ago the runner's last job started, in days from two days on
+func formatLastJob(summary *usecase.RunnerSummary, now time.Time) string {
+	if summary.LastJob == nil || summary.LastJob.StartedAt == nil {
+		return "-"
+	}
+	ago := now.Sub(*summary.LastJob.StartedAt)
+	if ago >= 48*time.Hour {
+		return fmt.Sprintf("%dd ago", int(ago/(24*time.Hour)))
+	}
+	return formatDuration(ago) + " ago"
+}
+
+// formatSuccessRate formats the success rate as a percentage, or "-" when no jobs have concluded
+func formatSuccessRate(summary *usecase.RunnerSummary) string {
+	rate, ok := summary.Stats.SuccessRate()
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", rate*100)
+}
+
+// getFleetColumnWidths sizes the fleet table to the terminal width
+func getFleetColumnWidths(terminalWidth int) []table.Column {
+	fixed := fleetStatusWidth + fleetBusyWidth + fleetOSWidth + fleetLastJobWidth + fleetJobsWidth + fleetSuccessWidth
+	// One space separates each of the 8 columns
+	available := terminalWidth - fixed - 7
+
+	nameWidth := max(available*2/5, minFleetNameWidth)
+	labelsWidth := max(available-nameWidth, minFleetLabelWidth)
+
+	return []table.Column{
+		{Title: "Name", Width: nameWidth},
+		{Title: "Status", Width: fleetStatusWidth},
+		{Title: "Busy", Width: fleetBusyWidth},
+		{Title: "OS", Width: fleetOSWidth},
+		{Title: "Labels", Width: labelsWidth},
+		{Title: "Last Job", Width: fleetLastJobWidth},
+		{Title: "Jobs", Width: fleetJobsWidth},
+		{Title: "Success", Width: fleetSuccessWidth},
+	}
+}
@@ -0,0 +1,116 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+func TestWriteFleetTable(t *testing.T) {
+	now := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	started := now.Add(-90 * time.Minute)
+	summary := &usecase.FleetSummary{
+		Runners: []*usecase.RunnerSummary{
+			{
+				Runner:  &entity.Runner{ID: 1, Name: "linux-a", Status: "online", OS: "linux", Labels: []string{"self-hosted", "linux"}, Busy: true},
+				LastJob: &entity.Job{ID: 10, Name: "build", StartedAt: &started},
+				Stats:   &usecase.JobStats{Total: 4, Completed: 3, Concluded: 3, Succeeded: 2},
+			},
+			{Runner: &entity.Runner{ID: 2, Name: "mac-a", Status: "offline", OS: "macOS"}, Stats: &usecase.JobStats{}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeFleetTable(&buf, summary, 120, now); err != nil {
+		t.Fatalf("writeFleetTable error: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+
+	if lines[0] != "Runners: 2 (online: 1, busy: 1, no jobs in window: 1)" {
+		t.Errorf("unexpected header: %q", lines[0])
+	}
+	if len(lines) != 5 {
+		t.Fatalf("expected header, blank line, column titles and 2 rows, got:\n%s", buf.String())
+	}
+
+	linuxRow := strings.Fields(lines[3])
+	expected := []string{"linux-a", "online", "yes", "linux", "self-hosted,linux", "1h", "30m", "ago", "4", "67%"}
+	if strings.Join(linuxRow, " ") != strings.Join(expected, " ") {
+		t.Errorf("unexpected row for linux-a: %q", lines[3])
+	}
+
+	macRow := strings.Fields(lines[4])
+	expected = []string{"mac-a", "offline", "no", "macOS", "-", "0", "-"}
+	if strings.Join(macRow, " ") != strings.Join(expected, " ") {
+		t.Errorf("unexpected row for mac-a: %q", lines[4])
+	}
+}
+
+func TestFormatLastJob(t *testing.T) {
+	now := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago      time.Duration
+		expected string
+	}{
+		{ago: 90 * time.Minute, expected: "1h 30m ago"},
+		{ago: 47 * time.Hour, expected: "47h 0m ago"},
+		{ago: 48 * time.Hour, expected: "2d ago"},
+		{ago: 8001*time.Hour + 30*time.Minute, expected: "333d ago"},
+	}
+	for _, tt := range tests {
+		started := now.Add(-tt.ago)
+		summary := &usecase.RunnerSummary{LastJob: &entity.Job{StartedAt: &started}}
+		if got := formatLastJob(summary, now); got != tt.expected {
+			t.Errorf("formatLastJob(%s) = %q, want %q", tt.ago, got, tt.expected)
+		}
+	}
+	if got := formatLastJob(&usecase.RunnerSummary{}, now); got != "-" {
+		t.Errorf("expected - without jobs, got %q", got)
+	}
+}
+
+func TestNewJSONFleet(t *testing.T) {
+	started := time.Date(2025, 11, 16, 10, 30, 0, 0, time.UTC)
+	summary := &usecase.FleetSummary{
+		Runners: []*usecase.RunnerSummary{
+			{
+				Runner:  &entity.Runner{ID: 1, Name: "linux-a", Status: "online", OS: "linux", Busy: true},
+				LastJob: &entity.Job{ID: 10, Name: "build", StartedAt: &started},
+				Stats:   &usecase.JobStats{Total: 4, Completed: 3, Concluded: 3, Succeeded: 2},
+			},
+			{Runner: &entity.Runner{ID: 2, Name: "mac-a", Status: "offline", OS: "macOS"}, Stats: &usecase.JobStats{}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, newJSONFleet(summary)); err != nil {
+		t.Fatalf("writeJSON error: %v", err)
+	}
+
+	var decoded map[string][]map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	runners := decoded["runners"]
+	if len(runners) != 2 {
+		t.Fatalf("expected 2 runners, got %d", len(runners))
+	}
+	if runners[0]["name"] != "linux-a" || runners[0]["busy"] != true || runners[0]["job_count"] != float64(4) {
+		t.Errorf("unexpected runner: %v", runners[0])
+	}
+	if lastJob, ok := runners[0]["last_job"].(map[string]interface{}); !ok || lastJob["id"] != float64(10) {
+		t.Errorf("unexpected last_job: %v", runners[0]["last_job"])
+	}
+	if runners[1]["last_job"] != nil || runners[1]["success_rate"] != nil {
+		t.Errorf("expected null last_job and success_rate for an idle runner, got %v", runners[1])
+	}
+	if labels, ok := runners[1]["labels"].([]interface{}); !ok || len(labels) != 0 {
+		t.Errorf("expected empty labels array, got %v", runners[1]["labels"])
+	}
+}
@@ -0,0 +1,234 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+// heatmapShades are the cells of the heatmap, from no load to the busiest hour
+var heatmapShades = []string{"··", "░░", "▒▒", "▓▓", "██"}
+
+// heatmapUncovered is the cell of an hour the window did not cover
+const heatmapUncovered = "  "
+
+// RunHeatmap fetches every job of the selected runners since the given time and writes
+// a weekday by hour heatmap of their load, or its JSON form with FormatJSON
+func (c *Controller) RunHeatmap(ctx context.Context, selector usecase.RunnerSelector, historyOpts usecase.HistoryOptions, since time.Time, metric usecase.HeatmapMetric, windowHours int) error {
+	switch c.opts.Format {
+	case FormatTUI, FormatJSON:
+	default:
+		return fmt.Errorf("unsupported output format %q for the heatmap", c.opts.Format)
+	}
+
+	report, err := c.runnerLogger.FetchRunnerHeatmap(ctx, selector, historyOpts, since)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.Format == FormatJSON {
+		err = writeDocument(c.opts, newJSONHeatmap(report, windowHours))
+	} else {
+		err = writeHeatmapReport(c.opts.Out, report, metric, windowHours, terminalWidth())
+	}
+	if err != nil {
+		return err
+	}
+
+	if report.History.IsPartial() {
+		writeFailureSummary(c.opts.ErrOut, report.History.Failures)
+		return fmt.Errorf("heatmap is incomplete: %s", describeFailures(report.History.Failures))
+	}
+	return nil
+}
+
+// jsonHeatmap is the JSON document written by the heatmap subcommand with --json
+type jsonHeatmap struct {
+	Runner         jsonRunner         `json:"runner"`
+	Runners        []*jsonRunner      `json:"runners,omitempty"`
+	RunnerPattern  string             `json:"runner_pattern,omitempty"`
+	From           time.Time          `json:"from"`
+	To             time.Time          `json:"to"`
+	TimeZone       string             `json:"time_zone"`
+	Cells          []jsonHeatmapCell  `json:"cells"`
+	QuietestWindow *jsonHeatmapWindow `json:"quietest_window"`
+	Failures       []jsonFailure      `json:"failures,omitempty"`
+}
+
+// jsonHeatmapCell is a single weekday and hour bucket of usecase.LoadHeatmap
+type jsonHeatmapCell struct {
+	Weekday            string  `json:"weekday"`
+	Hour               int     `json:"hour"`
+	Jobs               int     `json:"jobs"`
+	BusySeconds        float64 `json:"busy_seconds"`
+	CoveredHours       float64 `json:"covered_hours"`
+	JobsPerHour        float64 `json:"jobs_per_hour"`
+	BusySecondsPerHour float64 `json:"busy_seconds_per_hour"`
+}
+
+// jsonHeatmapWindow is the JSON form of usecase.HeatmapWindow
+type jsonHeatmapWindow struct {
+	Weekday     string  `json:"weekday"`
+	StartHour   int     `json:"start_hour"`
+	Hours       int     `json:"hours"`
+	Jobs        float64 `json:"jobs"`
+	BusySeconds float64 `json:"busy_seconds"`
+}
+
+// newJSONHeatmap converts the heatmap report into its JSON representation, with cells
+// ordered from Monday at midnight to Sunday at 23:00
+func newJSONHeatmap(report *usecase.RunnerHeatmap, windowHours int) jsonHeatmap {
+	history := newJSONHistory(report.History)
+	h := report.Heatmap
+	zone, _ := h.To.In(h.Location).Zone()
+
+	cells := make([]jsonHeatmapCell, 0, 7*24)
+	for _, day := range usecase.Weekdays {
+		for hour := 0; hour < 24; hour++ {
+			cells = append(cells, jsonHeatmapCell{
+				Weekday:            day.String(),
+				Hour:               hour,
+				Jobs:               h.Jobs[day][hour],
+				BusySeconds:        h.Busy[day][hour].Seconds(),
+				CoveredHours:       h.Covered[day][hour].Hours(),
+				JobsPerHour:        h.Value(usecase.HeatmapJobs, day, hour),
+				BusySecondsPerHour: h.Value(usecase.HeatmapBusy, day, hour) * 60,
+			})
+		}
+	}
+
+	doc := jsonHeatmap{
+		Runner:        history.Runner,
+		Runners:       history.Runners,
+		RunnerPattern: history.RunnerPattern,
+		From:          h.From,
+		To:            h.To,
+		TimeZone:      zone,
+		Cells:         cells,
+		Failures:      history.Failures,
+	}
+	if window, ok := h.QuietestWindow(windowHours); ok {
+		doc.QuietestWindow = &jsonHeatmapWindow{
+			Weekday:     window.Weekday.String(),
+			StartHour:   window.Hour,
+			Hours:       window.Hours,
+			Jobs:        window.Jobs,
+			BusySeconds: window.Busy.Seconds(),
+		}
+	}
+	return doc
+}
+
+// writeHeatmapReport writes the runner header, the heatmap and the quietest window
+func writeHeatmapReport(w io.Writer, report *usecase.RunnerHeatmap, metric usecase.HeatmapMetric, windowHours int, terminalWidth int) error {
+	h := report.Heatmap
+	zone, _ := h.To.In(h.Location).Zone()
+
+	var b strings.Builder
+	b.WriteString(renderHeader(report.History, terminalWidth))
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Window:        %s → %s (%s)\n", formatTime(&h.From), formatTime(&h.To), formatDuration(h.To.Sub(h.From)))
+	fmt.Fprintf(&b, "Time zone:     %s\n", zone)
+	b.WriteString("\n")
+	b.WriteString(renderHeatmap(h, metric))
+	b.WriteString("\n")
+	b.WriteString(renderQuietestWindow(h, windowHours))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderHeatmap draws one row per weekday and one column per hour, shaded relative to the
+// busiest hour, followed by the scale
+func renderHeatmap(h *usecase.LoadHeatmap, metric usecase.HeatmapMetric) string {
+	highest := h.Max(metric)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s per hour of day:\n", describeHeatmapMetric(metric))
+	b.WriteString("    ")
+	for hour := 0; hour < 24; hour++ {
+		fmt.Fprintf(&b, " %02d", hour)
+	}
+	b.WriteString("\n")
+
+	for _, day := range usecase.Weekdays {
+		b.WriteString(day.String()[:3] + " ")
+		for hour := 0; hour < 24; hour++ {
+			if !h.IsCovered(day, hour) {
+				b.WriteString(" " + heatmapUncovered)
+				continue
+			}
+			b.WriteString(" " + heatmapShade(h.Value(metric, day, hour), highest))
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "Scale: %s none  %s ≤25%%  %s ≤50%%  %s ≤75%%  %s ≤100%% of the busiest hour (%s)\n",
+		heatmapShades[0], heatmapShades[1], heatmapShades[2], heatmapShades[3], heatmapShades[4],
+		formatHeatmapValue(highest, metric),
+	)
+	if covered := h.CoveredHours(); covered < 7*24 {
+		fmt.Fprintf(&b, "Only %d of the 168 hours of the week are in the window, the blank ones are unknown; use --since 7d or more to cover them all\n", covered)
+	}
+	return b.String()
+}
+
+// renderHeatmapPanel renders the heatmap of the loaded jobs for the interactive UI
+func renderHeatmapPanel(h *usecase.LoadHeatmap, metric usecase.HeatmapMetric) string {
+	return renderHeatmap(h, metric) +
+		renderQuietestWindow(h, defaultQuietHours) +
+		"\nc busy time / jobs started  m table\n"
+}
+
+// renderQuietestWindow writes the quietest window line, or why there is none
+func renderQuietestWindow(h *usecase.LoadHeatmap, hours int) string {
+	window, ok := h.QuietestWindow(hours)
+	if !ok {
+		return fmt.Sprintf("Quietest %dh window: none, the window covers no %d consecutive hours\n", hours, hours)
+	}
+	return fmt.Sprintf("Quietest %dh window: %s\n", hours, formatHeatmapWindow(window))
+}
+
+// heatmapShade picks the shade of a bucket, in quarters of the busiest bucket
+func heatmapShade(value, highest float64) string {
+	if value <= 0 || highest <= 0 {
+		return heatmapShades[0]
+	}
+	level := int(math.Ceil(value / highest * 4))
+	return heatmapShades[min(max(level, 1), 4)]
+}
+
+// describeHeatmapMetric names the metric shown in the heatmap
+func describeHeatmapMetric(metric usecase.HeatmapMetric) string {
+	if metric == usecase.HeatmapJobs {
+		return "Jobs started"
+	}
+	return "Busy time"
+}
+
+// formatHeatmapValue formats a bucket value, busy minutes or jobs per hour
+func formatHeatmapValue(value float64, metric usecase.HeatmapMetric) string {
+	if metric == usecase.HeatmapJobs {
+		return fmt.Sprintf("%.1f jobs", value)
+	}
+	return formatDuration(time.Duration(value * float64(time.Minute)))
+}
+
+// formatHeatmapWindow describes a window of consecutive hours and the load inside it
+func formatHeatmapWindow(window usecase.HeatmapWindow) string {
+	endHour := window.Hour + window.Hours
+	endDay := (int(window.Weekday) + endHour/24) % 7
+	return fmt.Sprintf("%s %02d:00 → %s %02d:00 (%s busy, %.1f jobs)",
+		window.Weekday.String()[:3],
+		window.Hour,
+		time.Weekday(endDay).String()[:3],
+		endHour%24,
+		formatDuration(window.Busy.Round(time.Second)),
+		window.Jobs,
+	)
+}
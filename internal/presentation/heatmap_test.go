@@ -0,0 +1,164 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+func TestRenderHeatmap(t *testing.T) {
+	heatmap := &usecase.LoadHeatmap{Location: time.UTC}
+	for _, day := range usecase.Weekdays {
+		for hour := 0; hour < 24; hour++ {
+			heatmap.Busy[day][hour] = 10 * time.Minute
+			heatmap.Covered[day][hour] = time.Hour
+		}
+	}
+	heatmap.Busy[time.Monday][9] = 40 * time.Minute
+	heatmap.Busy[time.Wednesday][2] = 0
+
+	output := renderHeatmap(heatmap, usecase.HeatmapBusy)
+	lines := strings.Split(output, "\n")
+
+	if lines[0] != "Busy time per hour of day:" || !strings.HasPrefix(lines[1], "     00 01 02") {
+		t.Errorf("unexpected heading:\n%s", output)
+	}
+	monday := strings.Fields(lines[2])
+	if monday[0] != "Mon" || monday[1] != "░░" || monday[10] != "██" {
+		t.Errorf("unexpected Monday row: %q", lines[2])
+	}
+	if wednesday := strings.Fields(lines[4]); wednesday[0] != "Wed" || wednesday[3] != "··" {
+		t.Errorf("unexpected Wednesday row: %q", lines[4])
+	}
+	if !strings.HasSuffix(lines[9], "of the busiest hour (40m 0s)") {
+		t.Errorf("expected the busiest hour in the scale, got %q", lines[9])
+	}
+	if len(lines) != 11 {
+		t.Errorf("expected no coverage note for a full week:\n%s", output)
+	}
+}
+
+func TestRenderHeatmap_UncoveredHours(t *testing.T) {
+	// Friday 2025-11-21 from 09:00 to 12:00
+	from := time.Date(2025, 11, 21, 9, 0, 0, 0, time.UTC)
+	heatmap := usecase.ComputeLoadHeatmap(nil, from, from.Add(3*time.Hour), time.UTC)
+
+	output := renderHeatmap(heatmap, usecase.HeatmapBusy)
+	lines := strings.Split(output, "\n")
+
+	if friday := lines[6]; strings.Join(strings.Fields(friday), " ") != "Fri ·· ·· ··" || strings.Index(friday, "··") != len("Fri ")+9*3+1 {
+		t.Errorf("expected only Friday 09:00 to 12:00 to be shaded: %q", friday)
+	}
+	if monday := lines[2]; strings.TrimSpace(monday) != "Mon" {
+		t.Errorf("expected Monday to be blank: %q", monday)
+	}
+	if !strings.HasPrefix(lines[10], "Only 3 of the 168 hours of the week are in the window") {
+		t.Errorf("expected a coverage note, got %q", lines[10])
+	}
+	if got, want := renderQuietestWindow(heatmap, 4), "Quietest 4h window: none, the window covers no 4 consecutive hours\n"; got != want {
+		t.Errorf("renderQuietestWindow() = %q, want %q", got, want)
+	}
+}
+
+func TestWriteHeatmapReport(t *testing.T) {
+	// 2025-11-17 is a Monday
+	from := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC)
+	var jobs []*entity.Job
+	for hour := 0; hour < 7*24; hour++ {
+		// Every hour but Wednesday 02:00 has a ten minute job
+		if hour == 2*24+2 {
+			continue
+		}
+		started := from.Add(time.Duration(hour) * time.Hour)
+		completed := started.Add(10 * time.Minute)
+		jobs = append(jobs, &entity.Job{ID: int64(hour), Status: entity.StatusCompleted, StartedAt: &started, CompletedAt: &completed})
+	}
+	report := &usecase.RunnerHeatmap{
+		History: &usecase.RunnerJobHistory{
+			Runners: []*entity.Runner{{ID: 1, Name: "runner-a", Status: "online", OS: "linux"}},
+			Jobs:    jobs,
+		},
+		Heatmap: usecase.ComputeLoadHeatmap(jobs, from, from.Add(7*24*time.Hour), time.UTC),
+	}
+
+	var buf bytes.Buffer
+	if err := writeHeatmapReport(&buf, report, usecase.HeatmapJobs, 3, defaultTerminalWidth); err != nil {
+		t.Fatalf("writeHeatmapReport error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	if lines[0] != "Runner: runner-a" || lines[6] != "Time zone:     UTC" || lines[8] != "Jobs started per hour of day:" {
+		t.Errorf("unexpected header:\n%s", buf.String())
+	}
+	if quietest := lines[len(lines)-1]; quietest != "Quietest 3h window: Wed 00:00 → Wed 03:00 (20m 0s busy, 2.0 jobs)" {
+		t.Errorf("unexpected quietest window: %q", quietest)
+	}
+}
+
+func TestFormatHeatmapWindow(t *testing.T) {
+	window := usecase.HeatmapWindow{Weekday: time.Sunday, Hour: 22, Hours: 4, Jobs: 3, Busy: 5 * time.Minute}
+
+	if got, want := formatHeatmapWindow(window), "Sun 22:00 → Mon 02:00 (5m 0s busy, 3.0 jobs)"; got != want {
+		t.Errorf("formatHeatmapWindow() = %q, want %q", got, want)
+	}
+}
+
+func TestNewJSONHeatmap(t *testing.T) {
+	// 2025-11-17 is a Monday
+	from := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC)
+	heatmap := &usecase.LoadHeatmap{From: from, To: from.Add(7 * 24 * time.Hour), Location: time.UTC}
+	for _, day := range usecase.Weekdays {
+		for hour := 0; hour < 24; hour++ {
+			heatmap.Busy[day][hour] = 10 * time.Minute
+			heatmap.Jobs[day][hour] = 1
+			heatmap.Covered[day][hour] = time.Hour
+		}
+	}
+	heatmap.Busy[time.Monday][9] = 40 * time.Minute
+	heatmap.Jobs[time.Monday][9] = 4
+	heatmap.Busy[time.Wednesday][2] = 0
+	report := &usecase.RunnerHeatmap{
+		History: &usecase.RunnerJobHistory{Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}}},
+		Heatmap: heatmap,
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, newJSONHeatmap(report, 1)); err != nil {
+		t.Fatalf("writeJSON error: %v", err)
+	}
+
+	var decoded struct {
+		TimeZone string `json:"time_zone"`
+		Cells    []struct {
+			Weekday            string  `json:"weekday"`
+			Hour               int     `json:"hour"`
+			Jobs               int     `json:"jobs"`
+			BusySeconds        float64 `json:"busy_seconds"`
+			CoveredHours       float64 `json:"covered_hours"`
+			BusySecondsPerHour float64 `json:"busy_seconds_per_hour"`
+		} `json:"cells"`
+		QuietestWindow struct {
+			Weekday   string `json:"weekday"`
+			StartHour int    `json:"start_hour"`
+		} `json:"quietest_window"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if decoded.TimeZone != "UTC" || len(decoded.Cells) != 7*24 {
+		t.Fatalf("unexpected document: %s", buf.String())
+	}
+	if cell := decoded.Cells[9]; cell.Weekday != "Monday" || cell.Hour != 9 || cell.Jobs != 4 || cell.BusySeconds != 2400 ||
+		cell.CoveredHours != 1 || cell.BusySecondsPerHour != 2400 {
+		t.Errorf("unexpected cell: %+v", cell)
+	}
+	if decoded.QuietestWindow.Weekday != "Wednesday" || decoded.QuietestWindow.StartHour != 2 {
+		t.Errorf("unexpected quietest window: %+v", decoded.QuietestWindow)
+	}
+}
@@ -0,0 +1,234 @@
+package presentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+// jsonHistory is the JSON document written by --json.
+// Its field names, and those of the documents the subcommands write with --json, are part of
+// the CLI contract and documented in the README; only add new fields, never rename or remove
+// existing ones.
+type jsonHistory struct {
+	Runner        jsonRunner    `json:"runner"`
+	Runners       []*jsonRunner `json:"runners,omitempty"`
+	RunnerPattern string        `json:"runner_pattern,omitempty"`
+	Jobs          []jsonJob     `json:"jobs"`
+	Failures      []jsonFailure `json:"failures,omitempty"`
+}
+
+// jsonFailure describes a workflow run whose jobs are missing from the history
+type jsonFailure struct {
+	RunID      int64  `json:"run_id"`
+	Repository string `json:"repository"`
+	Error      string `json:"error"`
+}
+
+// jsonRunner is the JSON form of entity.Runner
+type jsonRunner struct {
+	ID     int64    `json:"id"`
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+	OS     string   `json:"os"`
+	Status string   `json:"status"`
+	Busy   bool     `json:"busy"`
+}
+
+// jsonJob is the JSON form of entity.Job
+type jsonJob struct {
+	ID              int64      `json:"id"`
+	RunID           int64      `json:"run_id"`
+	RunAttempt      int        `json:"run_attempt"`
+	Name            string     `json:"name"`
+	Status          string     `json:"status"`
+	Conclusion      string     `json:"conclusion"`
+	RunnerID        *int64     `json:"runner_id"`
+	RunnerName      *string    `json:"runner_name"`
+	StartedAt       *time.Time `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	WorkflowName    string     `json:"workflow_name"`
+	Repository      string     `json:"repository"`
+	HtmlURL         string     `json:"html_url"`
+	CreatedAt       *time.Time `json:"created_at"`
+	QueueSeconds    int64      `json:"queue_seconds"`
+	Steps           []jsonStep `json:"steps"`
+}
+
+// jsonStep is the JSON form of entity.Step
+type jsonStep struct {
+	Number          int        `json:"number"`
+	Name            string     `json:"name"`
+	Status          string     `json:"status"`
+	Conclusion      string     `json:"conclusion"`
+	StartedAt       *time.Time `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	DurationSeconds int64      `json:"duration_seconds"`
+}
+
+// newJSONHistory converts the use case result into its JSON representation
+func newJSONHistory(history *usecase.RunnerJobHistory) jsonHistory {
+	jobs := make([]jsonJob, 0, len(history.Jobs))
+	for _, job := range history.Jobs {
+		jobs = append(jobs, newJSONJob(job))
+	}
+
+	var runners []*jsonRunner
+	for _, runner := range history.Runners {
+		runners = append(runners, newJSONRunner(runner))
+	}
+
+	return jsonHistory{
+		Runner:        newJSONSelectedRunner(history),
+		Runners:       runners,
+		RunnerPattern: history.RunnerPattern,
+		Jobs:          jobs,
+		Failures:      newJSONFailures(history.Failures),
+	}
+}
+
+// newJSONFailures converts run failures into their JSON representation (none stays nil)
+func newJSONFailures(failures []repository.RunFailure) []jsonFailure {
+	var result []jsonFailure
+	for _, failure := range failures {
+		result = append(result, jsonFailure{
+			RunID:      failure.RunID,
+			Repository: failure.Repository,
+			Error:      failure.Err.Error(),
+		})
+	}
+	return result
+}
+
+// newJSONSelectedRunner describes the selected runners as a single runner object.
+// Scripts written before several runners could be selected read .runner, so it stays an object:
+// the runner itself when exactly one was selected, otherwise a summary of the selection with
+// id 0, the runner names (or patterns) as name, and only the labels, OS and status all of the
+// selected runners share. See runners and runner_pattern for the details.
+func newJSONSelectedRunner(history *usecase.RunnerJobHistory) jsonRunner {
+	if runner := history.SingleRunner(); runner != nil {
+		return *newJSONRunner(runner)
+	}
+
+	summary := jsonRunner{Labels: []string{}}
+	if history.RunnerPattern != "" {
+		summary.Name = history.RunnerPattern
+		return summary
+	}
+
+	names := make([]string, len(history.Runners))
+	for i, runner := range history.Runners {
+		names[i] = runner.Name
+		summary.Busy = summary.Busy || runner.Busy
+	}
+	summary.Name = strings.Join(names, ", ")
+	if len(history.Runners) == 0 {
+		return summary
+	}
+
+	first := history.Runners[0]
+	summary.OS, summary.Status = first.OS, first.Status
+	for _, label := range first.Labels {
+		if runnersShareLabel(history.Runners, label) {
+			summary.Labels = append(summary.Labels, label)
+		}
+	}
+	for _, runner := range history.Runners[1:] {
+		if runner.OS != summary.OS {
+			summary.OS = ""
+		}
+		if runner.Status != summary.Status {
+			summary.Status = ""
+		}
+	}
+	return summary
+}
+
+// runnersShareLabel reports whether every runner has the label
+func runnersShareLabel(runners []*entity.Runner, label string) bool {
+	for _, runner := range runners {
+		if !slices.Contains(runner.Labels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// newJSONRunner converts a runner entity into its JSON representation (nil stays nil)
+func newJSONRunner(runner *entity.Runner) *jsonRunner {
+	if runner == nil {
+		return nil
+	}
+
+	labels := runner.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	return &jsonRunner{
+		ID:     runner.ID,
+		Name:   runner.Name,
+		Labels: labels,
+		OS:     runner.OS,
+		Status: runner.Status,
+		Busy:   runner.Busy,
+	}
+}
+
+// newJSONJob converts a job entity into its JSON representation
+func newJSONJob(job *entity.Job) jsonJob {
+	return jsonJob{
+		ID:              job.ID,
+		RunID:           job.RunID,
+		RunAttempt:      job.RunAttempt,
+		Name:            job.Name,
+		Status:          job.Status,
+		Conclusion:      job.Conclusion,
+		RunnerID:        job.RunnerID,
+		RunnerName:      job.RunnerName,
+		StartedAt:       job.StartedAt,
+		CompletedAt:     job.CompletedAt,
+		DurationSeconds: int64(job.GetExecutionDuration().Seconds()),
+		WorkflowName:    job.WorkflowName,
+		Repository:      job.Repository,
+		HtmlURL:         job.HtmlUrl,
+		CreatedAt:       job.CreatedAt,
+		QueueSeconds:    int64(job.GetQueueDuration().Seconds()),
+		Steps:           newJSONSteps(job.Steps),
+	}
+}
+
+// newJSONSteps converts the steps of a job into their JSON representation
+func newJSONSteps(steps []entity.Step) []jsonStep {
+	docs := make([]jsonStep, 0, len(steps))
+	for _, step := range steps {
+		docs = append(docs, jsonStep{
+			Number:          step.Number,
+			Name:            step.Name,
+			Status:          step.Status,
+			Conclusion:      step.Conclusion,
+			StartedAt:       step.StartedAt,
+			CompletedAt:     step.CompletedAt,
+			DurationSeconds: int64(step.GetExecutionDuration().Seconds()),
+		})
+	}
+	return docs
+}
+
+// writeJSON writes a JSON document (such as jsonHistory) to w as indented JSON
+func writeJSON(w io.Writer, doc interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+func TestWriteJSON(t *testing.T) {
+	runnerID := int64(101)
+	runnerName := "runner-a"
+	created := time.Date(2025, 11, 16, 0, 59, 15, 0, time.UTC)
+	started := time.Date(2025, 11, 16, 1, 0, 0, 0, time.UTC)
+	completed := started.Add(4 * time.Minute)
+
+	history := &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: runnerID, Name: runnerName, OS: "linux", Status: "online"}},
+		Jobs: []*entity.Job{
+			{
+				ID:           1,
+				RunID:        1001,
+				RunAttempt:   2,
+				Name:         "build",
+				Status:       entity.StatusCompleted,
+				Conclusion:   "success",
+				RunnerID:     &runnerID,
+				RunnerName:   &runnerName,
+				CreatedAt:    &created,
+				StartedAt:    &started,
+				CompletedAt:  &completed,
+				WorkflowName: "CI",
+				Repository:   "owner/repo",
+				HtmlUrl:      "https://github.com/owner/repo/actions/runs/1001/job/1",
+				Steps: []entity.Step{
+					{Number: 1, Name: "Run make", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completed},
+				},
+			},
+			{ID: 2, RunID: 1002, Name: "deploy", Status: entity.StatusQueued},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, newJSONHistory(history)); err != nil {
+		t.Fatalf("writeJSON error: %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	runner := got["runner"].(map[string]any)
+	if runner["name"] != "runner-a" {
+		t.Errorf("runner.name = %v, want runner-a", runner["name"])
+	}
+	if labels, ok := runner["labels"].([]any); !ok || len(labels) != 0 {
+		t.Errorf("runner.labels = %v, want empty array", runner["labels"])
+	}
+
+	jobs := got["jobs"].([]any)
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+
+	first := jobs[0].(map[string]any)
+	expected := map[string]any{
+		"id":               float64(1),
+		"run_id":           float64(1001),
+		"run_attempt":      float64(2),
+		"name":             "build",
+		"status":           "completed",
+		"conclusion":       "success",
+		"runner_id":        float64(101),
+		"runner_name":      "runner-a",
+		"started_at":       "2025-11-16T01:00:00Z",
+		"completed_at":     "2025-11-16T01:04:00Z",
+		"duration_seconds": float64(240),
+		"workflow_name":    "CI",
+		"repository":       "owner/repo",
+		"html_url":         "https://github.com/owner/repo/actions/runs/1001/job/1",
+		"created_at":       "2025-11-16T00:59:15Z",
+		"queue_seconds":    float64(45),
+	}
+	for key, want := range expected {
+		if first[key] != want {
+			t.Errorf("jobs[0].%s = %v, want %v", key, first[key], want)
+		}
+	}
+
+	steps, ok := first["steps"].([]any)
+	if !ok || len(steps) != 1 {
+		t.Fatalf("jobs[0].steps = %v, want 1 step", first["steps"])
+	}
+	if step := steps[0].(map[string]any); step["name"] != "Run make" || step["number"] != float64(1) || step["duration_seconds"] != float64(240) {
+		t.Errorf("unexpected step: %v", step)
+	}
+
+	second := jobs[1].(map[string]any)
+	if steps, ok := second["steps"].([]any); !ok || len(steps) != 0 {
+		t.Errorf("jobs[1].steps = %v, want empty array", second["steps"])
+	}
+	if second["started_at"] != nil || second["runner_id"] != nil || second["queue_seconds"] != float64(0) {
+		t.Errorf("expected null started_at and runner_id for queued job, got %v", second)
+	}
+}
+
+func TestWriteJSONKeepsRunnerObjectForSeveralRunners(t *testing.T) {
+	tests := []struct {
+		name    string
+		history *usecase.RunnerJobHistory
+		want    jsonRunner
+	}{
+		{
+			name: "runners",
+			history: &usecase.RunnerJobHistory{Runners: []*entity.Runner{
+				{ID: 1, Name: "runner-a", OS: "linux", Status: "online", Labels: []string{"self-hosted", "linux", "gpu"}},
+				{ID: 2, Name: "runner-b", OS: "linux", Status: "offline", Labels: []string{"linux", "self-hosted"}, Busy: true},
+			}},
+			want: jsonRunner{Name: "runner-a, runner-b", OS: "linux", Labels: []string{"self-hosted", "linux"}, Busy: true},
+		},
+		{
+			name:    "pattern",
+			history: &usecase.RunnerJobHistory{RunnerPattern: "ephemeral-*"},
+			want:    jsonRunner{Name: "ephemeral-*", Labels: []string{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeJSON(&buf, newJSONHistory(tt.history)); err != nil {
+				t.Fatalf("writeJSON error: %v", err)
+			}
+
+			var got map[string]json.RawMessage
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+			}
+			var runner *jsonRunner
+			if err := json.Unmarshal(got["runner"], &runner); err != nil || runner == nil {
+				t.Fatalf("runner = %s, want an object", got["runner"])
+			}
+			if !reflect.DeepEqual(*runner, tt.want) {
+				t.Errorf("runner = %+v, want %+v", *runner, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,425 @@
+package presentation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logViewChrome is the number of lines of the log viewer besides the log: the title, a blank line
+// and the status line
+const logViewChrome = 3
+
+// Log viewer styles
+var (
+	logGroupStyle   = lipgloss.NewStyle().Bold(true)
+	logCursorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	logMatchStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("0")).Background(lipgloss.Color("220"))
+	logStatusStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	logCommandStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	logErrorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	logWarningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	logNoticeStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	logDebugStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+)
+
+// logLinePrefixes are the labels GitHub shows in front of annotation lines
+var logLinePrefixes = map[usecase.LogLineKind]string{
+	usecase.LogLineError:   "Error: ",
+	usecase.LogLineWarning: "Warning: ",
+	usecase.LogLineNotice:  "Notice: ",
+}
+
+// logLineStyles colour the lines written by workflow commands
+var logLineStyles = map[usecase.LogLineKind]lipgloss.Style{
+	usecase.LogLineCommand: logCommandStyle,
+	usecase.LogLineError:   logErrorStyle,
+	usecase.LogLineWarning: logWarningStyle,
+	usecase.LogLineNotice:  logNoticeStyle,
+	usecase.LogLineDebug:   logDebugStyle,
+}
+
+// logLoadedMsg is sent when the log of a job has been downloaded
+type logLoadedMsg struct {
+	job *entity.Job
+	log *usecase.JobLog
+	err error
+}
+
+// logPosition points at a line of the log
+type logPosition struct {
+	section int
+	// line is the index of the line in a group, or -1 for the section title
+	line int
+}
+
+// logViewer shows the log of a job, with foldable groups and search
+type logViewer struct {
+	job      *entity.Job
+	log      *usecase.JobLog
+	err      error
+	expanded []bool
+	// lines are the positions of the lines shown, skipping the lines of folded groups
+	lines     []logPosition
+	cursor    int
+	offset    int
+	height    int
+	searching bool
+	query     string
+	pattern   *regexp.Regexp
+	matches   []logPosition
+	match     int
+}
+
+// newLogViewer creates a viewer waiting for the log of the job
+func newLogViewer(job *entity.Job, height int) *logViewer {
+	return &logViewer{job: job, height: max(height, 1), match: -1}
+}
+
+// isLoading reports whether the log is still being downloaded
+func (v *logViewer) isLoading() bool {
+	return v.log == nil && v.err == nil
+}
+
+// setLog shows the downloaded log with its groups folded, except the group of the first error,
+// which the cursor is moved to
+func (v *logViewer) setLog(log *usecase.JobLog, err error) {
+	v.log, v.err = log, err
+	if err != nil {
+		return
+	}
+
+	v.expanded = make([]bool, len(log.Sections))
+	v.rebuildLines()
+	for s, section := range log.Sections {
+		if section.Title.Kind == usecase.LogLineError {
+			v.moveTo(logPosition{section: s, line: -1})
+			return
+		}
+		for l, line := range section.Lines {
+			if line.Kind == usecase.LogLineError {
+				v.moveTo(logPosition{section: s, line: l})
+				return
+			}
+		}
+	}
+}
+
+// rebuildLines lists the lines shown for the current folding
+func (v *logViewer) rebuildLines() {
+	v.lines = v.lines[:0]
+	for s, section := range v.log.Sections {
+		v.lines = append(v.lines, logPosition{section: s, line: -1})
+		if section.IsGroup && v.expanded[s] {
+			for l := range section.Lines {
+				v.lines = append(v.lines, logPosition{section: s, line: l})
+			}
+		}
+	}
+}
+
+// moveTo unfolds the group holding the line and moves the cursor onto it
+func (v *logViewer) moveTo(pos logPosition) {
+	if pos.line >= 0 && !v.expanded[pos.section] {
+		v.expanded[pos.section] = true
+		v.rebuildLines()
+	}
+	for i, line := range v.lines {
+		if line == pos {
+			v.cursor = i
+			break
+		}
+	}
+	v.scrollToCursor()
+}
+
+// move moves the cursor by delta lines, staying within the log
+func (v *logViewer) move(delta int) {
+	if len(v.lines) == 0 {
+		return
+	}
+	v.cursor = min(max(v.cursor+delta, 0), len(v.lines)-1)
+	v.scrollToCursor()
+}
+
+// scrollToCursor scrolls the least needed to show the cursor
+func (v *logViewer) scrollToCursor() {
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+v.height {
+		v.offset = v.cursor - v.height + 1
+	}
+	v.offset = max(min(v.offset, len(v.lines)-v.height), 0)
+}
+
+// setHeight changes the number of log lines shown, keeping the cursor visible
+func (v *logViewer) setHeight(height int) {
+	v.height = max(height, 1)
+	if v.log != nil {
+		v.scrollToCursor()
+	}
+}
+
+// toggleFold folds or unfolds the group under the cursor, leaving the cursor on its title
+func (v *logViewer) toggleFold() {
+	if len(v.lines) == 0 {
+		return
+	}
+	pos := v.lines[v.cursor]
+	if !v.log.Sections[pos.section].IsGroup {
+		return
+	}
+	v.expanded[pos.section] = !v.expanded[pos.section]
+	v.rebuildLines()
+	v.moveTo(logPosition{section: pos.section, line: -1})
+}
+
+// setAllExpanded folds or unfolds every group, leaving the cursor on the title of its section
+func (v *logViewer) setAllExpanded(expanded bool) {
+	if len(v.lines) == 0 {
+		return
+	}
+	pos := v.lines[v.cursor]
+	for s := range v.expanded {
+		v.expanded[s] = expanded
+	}
+	v.rebuildLines()
+	v.moveTo(logPosition{section: pos.section, line: -1})
+}
+
+// search finds the lines containing query, ignoring case and colours, and moves to the first
+// match after the cursor
+func (v *logViewer) search(query string) {
+	v.query = query
+	v.pattern, v.matches, v.match = nil, nil, -1
+	if query == "" {
+		return
+	}
+
+	v.pattern = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+	for s, section := range v.log.Sections {
+		if v.pattern.MatchString(ansi.Strip(section.Title.Text)) {
+			v.matches = append(v.matches, logPosition{section: s, line: -1})
+		}
+		for l, line := range section.Lines {
+			if v.pattern.MatchString(ansi.Strip(line.Text)) {
+				v.matches = append(v.matches, logPosition{section: s, line: l})
+			}
+		}
+	}
+	if len(v.matches) == 0 {
+		return
+	}
+
+	// Start from the first match at or after the cursor, wrapping around
+	v.match = 0
+	cursor := v.lines[v.cursor]
+	for i, pos := range v.matches {
+		if comparePositions(pos, cursor) >= 0 {
+			v.match = i
+			break
+		}
+	}
+	v.moveTo(v.matches[v.match])
+}
+
+// nextMatch moves to the next match, or to the previous one when dir is negative, wrapping around
+func (v *logViewer) nextMatch(dir int) {
+	if len(v.matches) == 0 {
+		return
+	}
+	v.match = (v.match + dir + len(v.matches)) % len(v.matches)
+	v.moveTo(v.matches[v.match])
+}
+
+// comparePositions orders positions as they appear in the log
+func comparePositions(a, b logPosition) int {
+	if a.section != b.section {
+		return a.section - b.section
+	}
+	return a.line - b.line
+}
+
+// handleKey updates the viewer for a key press and reports whether the viewer should be closed
+func (v *logViewer) handleKey(msg tea.KeyMsg) bool {
+	if v.searching {
+		switch msg.Type {
+		case tea.KeyEnter:
+			v.searching = false
+			v.search(v.query)
+		case tea.KeyEsc:
+			v.searching = false
+			v.search("")
+		case tea.KeyBackspace:
+			if runes := []rune(v.query); len(runes) > 0 {
+				v.query = string(runes[:len(runes)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			v.query += string(msg.Runes)
+		}
+		return false
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		return true
+	}
+	if v.log == nil {
+		return false
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		v.move(-1)
+	case "down", "j":
+		v.move(1)
+	case "pgup", "b":
+		v.move(-v.height)
+	case "pgdown", "f", " ":
+		v.move(v.height)
+	case "home", "g":
+		v.move(-len(v.lines))
+	case "end", "G":
+		v.move(len(v.lines))
+	case "enter", "tab":
+		v.toggleFold()
+	case "e":
+		v.setAllExpanded(true)
+	case "c":
+		v.setAllExpanded(false)
+	case "/":
+		v.searching = true
+		v.query = ""
+	case "n":
+		v.nextMatch(1)
+	case "N":
+		v.nextMatch(-1)
+	}
+	return false
+}
+
+// render renders the title, the visible part of the log and the status line
+func (v *logViewer) render(width int, spinner string) string {
+	var b strings.Builder
+	b.WriteString(statsTitleStyle.Render(ansi.Truncate(v.title(), width, "…")) + "\n\n")
+
+	switch {
+	case v.err != nil:
+		b.WriteString(warningStyle.Render("⚠ "+v.err.Error()) + "\n")
+		return padLines(b.String(), v.height+2) + logStatusStyle.Render("esc back") + "\n"
+	case v.log == nil:
+		b.WriteString(spinner + " Loading log...\n")
+		return padLines(b.String(), v.height+2) + logStatusStyle.Render("esc back") + "\n"
+	case len(v.lines) == 0:
+		b.WriteString("  The log is empty.\n")
+	}
+
+	end := min(v.offset+v.height, len(v.lines))
+	for i := v.offset; i < end; i++ {
+		b.WriteString(v.renderLine(v.lines[i], i == v.cursor, width) + "\n")
+	}
+	return padLines(b.String(), v.height+2) + v.renderStatus() + "\n"
+}
+
+// title describes the job whose log is shown and the position in the log
+func (v *logViewer) title() string {
+	title := fmt.Sprintf("Log of %s / %s (attempt %d on %s)", v.job.WorkflowName, v.job.Name, v.job.RunAttempt, formatRunnerName(v.job))
+	if len(v.lines) > v.height {
+		title += fmt.Sprintf(" – lines %d-%d of %d", v.offset+1, min(v.offset+v.height, len(v.lines)), len(v.lines))
+	}
+	if v.log != nil && v.log.Truncated {
+		title += fmt.Sprintf(" – only the last %d MiB of the log are shown", usecase.MaxJobLogBytes>>20)
+	}
+	return title
+}
+
+// renderLine renders a line of the log, truncated to width
+// Group titles show whether the group is folded, and lines inside groups are indented.
+func (v *logViewer) renderLine(pos logPosition, selected bool, width int) string {
+	gutter := " "
+	if selected {
+		gutter = logCursorStyle.Render("›")
+	}
+
+	section := v.log.Sections[pos.section]
+	var line string
+	switch {
+	case pos.line >= 0:
+		line = "    " + v.formatLogLine(section.Lines[pos.line])
+	case section.IsGroup && v.expanded[pos.section]:
+		line = "▾ " + logGroupStyle.Render(v.formatLogLine(section.Title))
+	case section.IsGroup:
+		lines := "lines"
+		if len(section.Lines) == 1 {
+			lines = "line"
+		}
+		line = "▸ " + logGroupStyle.Render(v.formatLogLine(section.Title)) +
+			logStatusStyle.Render(fmt.Sprintf(" (%d %s)", len(section.Lines), lines))
+	default:
+		line = "  " + v.formatLogLine(section.Title)
+	}
+
+	line = ansi.Truncate(gutter+line, width, "…")
+	if strings.Contains(line, "\x1b") {
+		// Colours left open by the job must not run into the next line
+		line += ansi.ResetStyle
+	}
+	return line
+}
+
+// formatLogLine labels and colours annotation and command lines, and highlights search matches
+func (v *logViewer) formatLogLine(line usecase.LogLine) string {
+	text := strings.ReplaceAll(line.Text, "\t", "    ")
+	if v.pattern != nil {
+		text = highlightMatches(text, v.pattern)
+	}
+	text = logLinePrefixes[line.Kind] + text
+	if style, ok := logLineStyles[line.Kind]; ok {
+		return style.Render(text)
+	}
+	return text
+}
+
+// highlightMatches highlights every match of pattern in text
+// Lines with matches lose their own colours so that the highlighting stays readable.
+func highlightMatches(text string, pattern *regexp.Regexp) string {
+	plain := ansi.Strip(text)
+	indexes := pattern.FindAllStringIndex(plain, -1)
+	if len(indexes) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, index := range indexes {
+		b.WriteString(plain[last:index[0]])
+		b.WriteString(logMatchStyle.Render(plain[index[0]:index[1]]))
+		last = index[1]
+	}
+	b.WriteString(plain[last:])
+	return b.String()
+}
+
+// renderStatus renders the search prompt while typing a query, or the search results and keys
+func (v *logViewer) renderStatus() string {
+	if v.searching {
+		return "/" + v.query + "█"
+	}
+
+	status := "enter fold • e/c expand/collapse all • / search • esc back"
+	if v.query != "" {
+		if len(v.matches) == 0 {
+			status = fmt.Sprintf("No matches for %q • %s", v.query, status)
+		} else {
+			status = fmt.Sprintf("Match %d of %d for %q • n/N next/previous • %s", v.match+1, len(v.matches), v.query, status)
+		}
+	}
+	return logStatusStyle.Render(status)
+}
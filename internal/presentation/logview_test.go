@@ -0,0 +1,231 @@
+package presentation
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// newTestLogViewer creates a viewer showing a log with two groups, the second holding an error
+func newTestLogViewer(height int) *logViewer {
+	job := &entity.Job{ID: 1, Name: "build", WorkflowName: "CI", RunAttempt: 1}
+	v := newLogViewer(job, height)
+	v.setLog(&usecase.JobLog{Job: job, Sections: usecase.ParseJobLog(strings.Join([]string{
+		"Current runner version: '2.330.0'",
+		"##[group]Run actions/checkout@v4",
+		"Syncing repository: acme/app",
+		"##[endgroup]",
+		"##[group]Run go test ./...",
+		"ok  \tacme/app/cli",
+		"--- FAIL: TestBuild",
+		"##[error]Process completed with exit code 1.",
+		"##[endgroup]",
+		"Cleaning up orphan processes",
+	}, "\n"))}, nil)
+	return v
+}
+
+// typeKeys sends each rune of keys to the viewer
+func typeKeys(v *logViewer, keys string) {
+	for _, r := range keys {
+		v.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestLogViewer_OpensOnFirstError(t *testing.T) {
+	v := newTestLogViewer(20)
+
+	if v.expanded[1] || !v.expanded[2] {
+		t.Errorf("expected only the group with the error to be unfolded, got %v", v.expanded)
+	}
+	if pos := v.lines[v.cursor]; pos != (logPosition{section: 2, line: 2}) {
+		t.Errorf("expected the cursor on the error, got %+v", pos)
+	}
+
+	output := ansi.Strip(v.render(80, ""))
+	for _, expected := range []string{
+		"Log of CI / build (attempt 1 on -)",
+		"▸ Run actions/checkout@v4 (1 line)",
+		"▾ Run go test ./...",
+		"    ok      acme/app/cli",
+		"›    Error: Process completed with exit code 1.",
+		"  Cleaning up orphan processes",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, output)
+		}
+	}
+	if lines := strings.Count(output, "\n"); lines != 20+logViewChrome {
+		t.Errorf("expected %d lines, got %d", 20+logViewChrome, lines)
+	}
+}
+
+func TestLogViewer_Folding(t *testing.T) {
+	v := newTestLogViewer(20)
+
+	// Folding from inside a group leaves the cursor on its title
+	v.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if v.expanded[2] || v.lines[v.cursor] != (logPosition{section: 2, line: -1}) {
+		t.Errorf("expected the group to be folded with the cursor on its title, got %+v", v.lines[v.cursor])
+	}
+	if len(v.lines) != 4 {
+		t.Errorf("expected 4 lines with every group folded, got %d", len(v.lines))
+	}
+
+	typeKeys(v, "e")
+	if len(v.lines) != 8 {
+		t.Errorf("expected 8 lines with every group unfolded, got %d", len(v.lines))
+	}
+	typeKeys(v, "c")
+	if len(v.lines) != 4 {
+		t.Errorf("expected 4 lines with every group folded, got %d", len(v.lines))
+	}
+}
+
+func TestLogViewer_Search(t *testing.T) {
+	v := newTestLogViewer(20)
+	typeKeys(v, "gc/")
+	if !v.searching {
+		t.Fatal("expected / to start a search")
+	}
+	typeKeys(v, "ACME")
+	v.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Matches are found in folded groups, ignoring case
+	if len(v.matches) != 2 || v.match != 0 {
+		t.Fatalf("expected 2 matches starting with the first, got %+v (current %d)", v.matches, v.match)
+	}
+	if !v.expanded[1] || v.lines[v.cursor] != (logPosition{section: 1, line: 0}) {
+		t.Errorf("expected the first match to be unfolded and selected, got %+v", v.lines[v.cursor])
+	}
+	if status := ansi.Strip(v.renderStatus()); !strings.HasPrefix(status, `Match 1 of 2 for "ACME"`) {
+		t.Errorf("unexpected status: %q", status)
+	}
+
+	typeKeys(v, "n")
+	if v.lines[v.cursor] != (logPosition{section: 2, line: 0}) {
+		t.Errorf("expected the second match, got %+v", v.lines[v.cursor])
+	}
+	// Wraps around
+	typeKeys(v, "n")
+	if v.match != 0 {
+		t.Errorf("expected n to wrap to the first match, got %d", v.match)
+	}
+	typeKeys(v, "N")
+	if v.match != 1 {
+		t.Errorf("expected N to wrap to the last match, got %d", v.match)
+	}
+}
+
+func TestLogViewer_Scrolling(t *testing.T) {
+	v := newTestLogViewer(3)
+	typeKeys(v, "g")
+	if v.cursor != 0 || v.offset != 0 {
+		t.Fatalf("expected the top of the log, got cursor %d offset %d", v.cursor, v.offset)
+	}
+
+	typeKeys(v, "jjjj")
+	if v.cursor != 4 || v.offset != 2 {
+		t.Errorf("expected the log to scroll with the cursor, got cursor %d offset %d", v.cursor, v.offset)
+	}
+	typeKeys(v, "G")
+	if v.cursor != len(v.lines)-1 || v.offset != len(v.lines)-3 {
+		t.Errorf("expected the end of the log, got cursor %d offset %d", v.cursor, v.offset)
+	}
+	if title := v.title(); !strings.HasSuffix(title, "lines 5-7 of 7") {
+		t.Errorf("unexpected title: %q", title)
+	}
+}
+
+func TestLogViewer_Error(t *testing.T) {
+	v := newLogViewer(&entity.Job{Name: "build", WorkflowName: "CI"}, 5)
+	if !v.isLoading() {
+		t.Fatal("expected the viewer to wait for the log")
+	}
+	v.setLog(nil, errors.New("not found"))
+
+	if v.isLoading() || !strings.Contains(v.render(80, ""), "not found") {
+		t.Errorf("expected the error to be shown:\n%s", v.render(80, ""))
+	}
+	if !v.handleKey(tea.KeyMsg{Type: tea.KeyEsc}) {
+		t.Error("expected esc to close the viewer")
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	got := highlightMatches("\x1b[32mok\x1b[0m acme OK", regexp.MustCompile("(?i)ok"))
+
+	if ansi.Strip(got) != "ok acme OK" {
+		t.Errorf("expected the text to be kept, got %q", ansi.Strip(got))
+	}
+	if strings.Contains(got, "\x1b[32m") {
+		t.Errorf("expected the job's colours to be dropped from matched lines, got %q", got)
+	}
+	if text := highlightMatches("no match", regexp.MustCompile("ok")); text != "no match" {
+		t.Errorf("expected lines without matches to be unchanged, got %q", text)
+	}
+}
+
+func TestModel_LogViewer(t *testing.T) {
+	history := &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}},
+		Jobs:    []*entity.Job{{ID: 1, Name: "build", Status: entity.StatusCompleted, Conclusion: "success"}},
+	}
+	m := NewModel(history)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if m.logView == nil || cmd == nil {
+		t.Fatal("expected v to open the log viewer and download the log")
+	}
+	if view := m.View(); !strings.Contains(view, "Loading log...") {
+		t.Errorf("expected a loading message:\n%s", view)
+	}
+
+	// A log for another job is ignored
+	m.Update(logLoadedMsg{job: &entity.Job{ID: 2, Status: entity.StatusCompleted}, err: errors.New("stale")})
+	if !m.logView.isLoading() {
+		t.Error("expected the log of another job to be ignored")
+	}
+	m.Update(logLoadedMsg{job: history.Jobs[0], log: &usecase.JobLog{Job: history.Jobs[0], Sections: usecase.ParseJobLog("hello")}})
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "hello") {
+		t.Errorf("expected the log to be shown:\n%s", view)
+	}
+
+	// q closes the viewer instead of quitting
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if m.logView != nil || m.quitting {
+		t.Error("expected q to close the log viewer")
+	}
+}
+
+func TestModel_LogViewerNeedsFinishedJob(t *testing.T) {
+	history := &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}},
+		Jobs:    []*entity.Job{{ID: 1, Name: "build", Status: entity.StatusInProgress}},
+	}
+	m := NewModel(history)
+	height := m.tableHeight()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if m.logView != nil || cmd != nil {
+		t.Fatal("expected no log to be downloaded for a running job")
+	}
+	if view := m.View(); !strings.Contains(view, "The log of build is available once the job has finished") {
+		t.Errorf("expected a notice:\n%s", view)
+	}
+	if m.tableHeight() != height-1 {
+		t.Errorf("expected the table to make room for the notice, got height %d", m.tableHeight())
+	}
+
+	// The notice goes away with the next key
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.notice != "" || m.tableHeight() != height {
+		t.Errorf("expected the notice to be cleared, got %q", m.notice)
+	}
+}
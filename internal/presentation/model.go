@@ -4,6 +4,8 @@ import (
 	"context"
 	"os/exec"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
 	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
@@ -17,9 +19,11 @@ import (
 const (
 	minWorkflowWidth     = 20
 	minJobWidth          = 20
+	minRunnerWidth       = 20
 	attemptWidth         = 8
 	statusWidth          = 12
 	conclusionWidth      = 12
+	queuedWidth          = 10
 	startedAtWidth       = 25
 	durationWidth        = 15
 	borderPadding        = 10
@@ -30,22 +34,57 @@ const (
 	// Proportions for distributing extra width (only for Workflow and Job)
 	ratioWorkflow = 0.5
 	ratioJob      = 0.5
+
+	// Proportions for distributing extra width when the Runner column is shown
+	ratioWorkflowWithRunner = 0.35
+	ratioJobWithRunner      = 0.35
+	ratioRunner             = 0.3
+)
+
+// defaultQuietHours is the length of the quietest window shown with the heatmap
+const defaultQuietHours = 4
+
+// viewMode selects what the interactive UI shows below the header
+type viewMode int
+
+const (
+	viewTable viewMode = iota
+	viewTimeline
+	viewHeatmap
 )
 
 // Model represents the application state for the TUI
 type Model struct {
-	table        table.Model
-	spinner      spinner.Model
-	history      *usecase.RunnerJobHistory
-	choice       *entity.Job
-	loading      bool
-	quitting     bool
-	runnerLogger *usecase.RunnerLogger
-	runnerName   string
-	maxCount     int
-	width        int
-	height       int
-	err          error
+	table         table.Model
+	spinner       spinner.Model
+	history       *usecase.RunnerJobHistory
+	stats         *usecase.JobStats
+	showStats     bool
+	showDetail    bool
+	logView       *logViewer
+	notice        string
+	regressions   []*usecase.JobRegressions
+	timeline      *timeline
+	heatmap       *usecase.LoadHeatmap
+	heatmapMetric usecase.HeatmapMetric
+	mode          viewMode
+	choice        *entity.Job
+	loading       bool
+	quitting      bool
+	runnerLogger  *usecase.RunnerLogger
+	ctx           context.Context
+	cancel        context.CancelFunc
+	selector      usecase.RunnerSelector
+	historyOpts   usecase.HistoryOptions
+	from          time.Time
+	watch         time.Duration
+	refreshing    bool
+	lastRefresh   time.Time
+	refreshErr    error
+	changed       map[int64]bool
+	width         int
+	height        int
+	err           error
 }
 
 // historyLoadedMsg is sent when history is loaded
@@ -60,91 +99,105 @@ func NewModel(history *usecase.RunnerJobHistory) *Model {
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
-	var t table.Model
-	loading := history == nil
-
-	if !loading {
-		columns := []table.Column{
-			{Title: "Workflow", Width: 20},
-			{Title: "Job", Width: 20},
-			{Title: "Attempt", Width: 8},
-			{Title: "Status", Width: 12},
-			{Title: "Conclusion", Width: 12},
-			{Title: "Started At", Width: 25},
-			{Title: "Duration", Width: 15},
-		}
-
-		rows := buildRows(history.Jobs)
-
-		t = table.New(
-			table.WithColumns(columns),
-			table.WithRows(rows),
-			table.WithFocused(true),
-			table.WithHeight(20),
-		)
-
-		ts := table.DefaultStyles()
-		ts.Header = ts.Header.
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			BorderBottom(true).
-			Bold(false)
-		ts.Selected = ts.Selected.
-			Foreground(lipgloss.Color("229")).
-			Background(lipgloss.Color("57")).
-			Bold(false)
-		t.SetStyles(ts)
-		t.Focus()
+	m := &Model{
+		spinner:       s,
+		history:       history,
+		heatmapMetric: usecase.HeatmapBusy,
+		loading:       history == nil,
+		width:         defaultTerminalWidth,
+		height:        defaultTableHeight + headerFooterHeight,
 	}
-
-	return &Model{
-		table:   t,
-		spinner: s,
-		history: history,
-		loading: loading,
-		width:   defaultTerminalWidth,
-		height:  defaultTableHeight + headerFooterHeight,
+	if !m.loading {
+		m.buildTable()
 	}
+	return m
+}
+
+// buildTable creates the job table for the loaded history, sized to the terminal
+func (m *Model) buildTable() {
+	showRunner := m.history.IsMultiRunner()
+	columns := getCalculatedColumnWidths(m.width, showRunner)
+	m.regressions = usecase.DetectDurationRegressions(m.history.Jobs, defaultRegressionOptions)
+	rows := m.tableRows()
+	now := time.Now()
+	m.stats = computeLoadedJobStats(m.history.Jobs, now)
+	m.timeline = newTimeline(m.history.Jobs, m.from, now)
+	m.heatmap = usecase.ComputeLoadHeatmap(m.history.Jobs, m.timeline.from, now, time.Local)
+
+	tableHeight := m.tableHeight()
+	m.table = table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(tableHeight),
+	)
+
+	ts := table.DefaultStyles()
+	ts.Header = ts.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(false)
+	ts.Selected = ts.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("57")).
+		Bold(false)
+	m.table.SetStyles(ts)
+	m.table.Focus()
+}
+
+// tableRows builds the table rows of the loaded jobs with their regression and change markers
+func (m *Model) tableRows() []table.Row {
+	rows := buildRows(m.history.Jobs, m.history.IsMultiRunner())
+	markRegressions(rows, m.history.Jobs, m.regressions)
+	markChanged(rows, m.history.Jobs, m.changed)
+	return rows
 }
 
 // getCalculatedColumnWidths calculates column widths based on available terminal width
-func getCalculatedColumnWidths(terminalWidth int) []table.Column {
+// Workflow and Job (and Runner, when shown) share the width left over by the fixed columns.
+func getCalculatedColumnWidths(terminalWidth int, showRunner bool) []table.Column {
 	if terminalWidth == 0 {
 		terminalWidth = defaultTerminalWidth
 	}
 
 	availableWidth := terminalWidth - borderPadding
-	fixedWidth := attemptWidth + statusWidth + conclusionWidth + startedAtWidth + durationWidth
+	fixedWidth := attemptWidth + statusWidth + conclusionWidth + queuedWidth + startedAtWidth + durationWidth
 	totalMinWidth := minWorkflowWidth + minJobWidth + fixedWidth
+	if showRunner {
+		totalMinWidth += minRunnerWidth
+	}
 
-	if availableWidth < totalMinWidth {
-		// Terminal is too small, use minimum widths
-		return []table.Column{
-			{Title: "Workflow", Width: minWorkflowWidth},
-			{Title: "Job", Width: minJobWidth},
-			{Title: "Attempt", Width: attemptWidth},
-			{Title: "Status", Width: statusWidth},
-			{Title: "Conclusion", Width: conclusionWidth},
-			{Title: "Started At", Width: startedAtWidth},
-			{Title: "Duration", Width: durationWidth},
+	workflowWidth, jobWidth, runnerWidth := minWorkflowWidth, minJobWidth, minRunnerWidth
+
+	// When the terminal is too small, use minimum widths
+	if remainingWidth := availableWidth - totalMinWidth; remainingWidth > 0 {
+		// Distribute remaining width proportionally between the flexible columns
+		if showRunner {
+			workflowWidth += int(float64(remainingWidth) * ratioWorkflowWithRunner)
+			jobWidth += int(float64(remainingWidth) * ratioJobWithRunner)
+			runnerWidth += int(float64(remainingWidth) * ratioRunner)
+		} else {
+			workflowWidth += int(float64(remainingWidth) * ratioWorkflow)
+			jobWidth += int(float64(remainingWidth) * ratioJob)
 		}
 	}
 
-	remainingWidth := availableWidth - totalMinWidth
-
-	// Distribute remaining width proportionally between Workflow and Job
-	workflowExtra := int(float64(remainingWidth) * ratioWorkflow)
-	jobExtra := int(float64(remainingWidth) * ratioJob)
-
-	return []table.Column{
-		{Title: "Workflow", Width: minWorkflowWidth + workflowExtra},
-		{Title: "Job", Width: minJobWidth + jobExtra},
-		{Title: "Attempt", Width: attemptWidth},
-		{Title: "Status", Width: statusWidth},
-		{Title: "Conclusion", Width: conclusionWidth},
-		{Title: "Started At", Width: startedAtWidth},
-		{Title: "Duration", Width: durationWidth},
+	columns := []table.Column{
+		{Title: "Workflow", Width: workflowWidth},
+		{Title: "Job", Width: jobWidth},
 	}
+	if showRunner {
+		columns = append(columns, table.Column{Title: "Runner", Width: runnerWidth})
+	}
+	return append(columns,
+		table.Column{Title: "Attempt", Width: attemptWidth},
+		table.Column{Title: "Status", Width: statusWidth},
+		table.Column{Title: "Conclusion", Width: conclusionWidth},
+		table.Column{Title: "Queued", Width: queuedWidth},
+		table.Column{Title: "Started At", Width: startedAtWidth},
+		table.Column{Title: "Duration", Width: durationWidth},
+	)
 }
 
 // getCalculatedTableHeight calculates table height based on terminal height
@@ -161,11 +214,23 @@ func getCalculatedTableHeight(terminalHeight int) int {
 
 // updateTableDimensions updates the table dimensions based on current terminal size
 func (m *Model) updateTableDimensions() {
-	columns := getCalculatedColumnWidths(m.width)
+	columns := getCalculatedColumnWidths(m.width, m.history.IsMultiRunner())
 	m.table.SetColumns(columns)
 
-	tableHeight := getCalculatedTableHeight(m.height)
-	m.table.SetHeight(tableHeight)
+	m.table.SetHeight(m.tableHeight())
+}
+
+// tableHeight returns the table height, leaving room for the banners and for the statistics
+// panel and the detail pane when they are shown
+func (m *Model) tableHeight() int {
+	height := m.height - strings.Count(m.renderBanners(), "\n")
+	if m.showStats {
+		height -= strings.Count(renderStatsPanel(m.stats), "\n")
+	}
+	if m.showDetail {
+		height -= detailPaneHeight
+	}
+	return getCalculatedTableHeight(height)
 }
 
 func (m *Model) Init() tea.Cmd {
@@ -178,10 +243,23 @@ func (m *Model) Init() tea.Cmd {
 	return nil
 }
 
+// fetchJobLog downloads the log of the job
+func (m *Model) fetchJobLog(job *entity.Job) tea.Cmd {
+	return func() tea.Msg {
+		log, err := m.runnerLogger.FetchJobLog(m.ctx, job)
+		return logLoadedMsg{job: job, log: log, err: err}
+	}
+}
+
+// logViewHeight returns the number of log lines the log viewer shows
+func (m *Model) logViewHeight() int {
+	return max(m.height-logViewChrome, 1)
+}
+
 // fetchHistory fetches the runner job history
 func (m *Model) fetchHistory() tea.Cmd {
 	return func() tea.Msg {
-		history, err := m.runnerLogger.FetchRunnerJobHistory(context.Background(), m.runnerName, m.maxCount)
+		history, err := m.runnerLogger.FetchRunnerJobHistory(m.ctx, m.selector, m.historyOpts)
 		return historyLoadedMsg{history: history, err: err}
 	}
 }
@@ -195,9 +273,25 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !m.loading {
 			m.updateTableDimensions()
 		}
+		if m.logView != nil {
+			m.logView.setHeight(m.logViewHeight())
+		}
 		return m, nil
 
 	case historyLoadedMsg:
+		if !m.loading {
+			// A refresh in watch mode; keep showing the previous history if it failed
+			m.refreshing = false
+			if msg.err != nil {
+				m.refreshErr = msg.err
+			} else {
+				m.refreshErr = nil
+				m.applyRefresh(msg.history)
+			}
+			// The watch status changes with the refresh
+			m.updateTableDimensions()
+			return m, m.scheduleRefresh()
+		}
 		if msg.err != nil {
 			m.err = msg.err
 			m.loading = false
@@ -205,44 +299,128 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.history = msg.history
 		m.loading = false
+		m.lastRefresh = time.Now()
 
 		// Build table now that we have data
-		columns := getCalculatedColumnWidths(m.width)
-		rows := buildRows(m.history.Jobs)
-
-		tableHeight := getCalculatedTableHeight(m.height)
-		m.table = table.New(
-			table.WithColumns(columns),
-			table.WithRows(rows),
-			table.WithFocused(true),
-			table.WithHeight(tableHeight),
-		)
+		m.buildTable()
+		return m, tea.Batch(m.scheduleRefresh(), m.scheduleClock())
+
+	case logLoadedMsg:
+		// Ignore the log of a viewer that has been closed
+		if m.logView != nil && m.logView.job == msg.job {
+			m.logView.setLog(msg.log, msg.err)
+		}
+		return m, nil
 
-		ts := table.DefaultStyles()
-		ts.Header = ts.Header.
-			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			BorderBottom(true).
-			Bold(false)
-		ts.Selected = ts.Selected.
-			Foreground(lipgloss.Color("229")).
-			Background(lipgloss.Color("57")).
-			Bold(false)
-		m.table.SetStyles(ts)
-		m.table.Focus()
+	case refreshTickMsg:
+		if !m.refreshing {
+			m.refreshing = true
+			m.updateTableDimensions()
+			return m, m.fetchHistory()
+		}
 		return m, nil
 
+	case clockTickMsg:
+		m.updateRunningDurations()
+		return m, m.scheduleClock()
+
 	case tea.KeyMsg:
+		if m.logView != nil && msg.String() != "ctrl+c" {
+			// The log viewer takes every key until it is closed
+			if m.logView.handleKey(msg) {
+				m.logView = nil
+			}
+			return m, nil
+		}
+		// Notices only answer the previous key
+		if m.notice != "" {
+			m.notice = ""
+			m.updateTableDimensions()
+		}
 		switch msg.String() {
 		case "q", "ctrl+c":
 			m.quitting = true
+			// Abort any requests still in flight
+			if m.cancel != nil {
+				m.cancel()
+			}
 			return m, tea.Quit
+		case "s":
+			if !m.loading {
+				m.showStats = !m.showStats
+				m.updateTableDimensions()
+				return m, nil
+			}
+		case "i":
+			// d is the table's half page down
+			if !m.loading {
+				m.showDetail = !m.showDetail
+				m.updateTableDimensions()
+				return m, nil
+			}
+		case "v":
+			if !m.loading {
+				job := m.selectedJob()
+				switch {
+				case job == nil:
+				case !job.IsCompleted():
+					// GitHub only provides the log once the job has finished
+					m.notice = "The log of " + job.Name + " is available once the job has finished"
+					m.updateTableDimensions()
+				default:
+					m.logView = newLogViewer(job, m.logViewHeight())
+					return m, tea.Batch(m.spinner.Tick, m.fetchJobLog(job))
+				}
+				return m, nil
+			}
+		case "t":
+			if !m.loading {
+				m.toggleMode(viewTimeline)
+				return m, nil
+			}
+		case "m":
+			if !m.loading {
+				m.toggleMode(viewHeatmap)
+				return m, nil
+			}
+		case "c":
+			if m.mode == viewHeatmap {
+				if m.heatmapMetric == usecase.HeatmapBusy {
+					m.heatmapMetric = usecase.HeatmapJobs
+				} else {
+					m.heatmapMetric = usecase.HeatmapBusy
+				}
+				return m, nil
+			}
+		case "+", "=":
+			if m.mode == viewTimeline {
+				m.timeline.zoomIn()
+				return m, nil
+			}
+		case "-":
+			if m.mode == viewTimeline {
+				m.timeline.zoomOut()
+				return m, nil
+			}
+		case "left", "h":
+			if m.mode == viewTimeline {
+				m.timeline.pan(-1)
+				return m, nil
+			}
+		case "right", "l":
+			if m.mode == viewTimeline {
+				m.timeline.pan(1)
+				return m, nil
+			}
+		case "0":
+			if m.mode == viewTimeline {
+				m.timeline.reset()
+				return m, nil
+			}
 		case "enter":
 			if !m.loading {
-				// Get selected job
-				selectedIdx := m.table.Cursor()
-				if selectedIdx >= 0 && selectedIdx < len(m.history.Jobs) {
-					m.choice = m.history.Jobs[selectedIdx]
+				if job := m.selectedJob(); job != nil {
+					m.choice = job
 					// Open browser but don't quit
 					go openBrowserAsync(m.choice.HtmlUrl)
 				}
@@ -250,14 +428,38 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	if m.loading {
+	switch {
+	case m.loading:
 		m.spinner, cmd = m.spinner.Update(msg)
-	} else {
+	case m.logView != nil:
+		// Keep the spinner going until the log is downloaded
+		if m.logView.isLoading() {
+			m.spinner, cmd = m.spinner.Update(msg)
+		}
+	default:
 		m.table, cmd = m.table.Update(msg)
 	}
 	return m, cmd
 }
 
+// toggleMode switches between the job table and the given view
+func (m *Model) toggleMode(mode viewMode) {
+	if m.mode == mode {
+		m.mode = viewTable
+	} else {
+		m.mode = mode
+	}
+}
+
+// selectedJob returns the job under the table cursor, or nil when there is none
+func (m *Model) selectedJob() *entity.Job {
+	selectedIdx := m.table.Cursor()
+	if selectedIdx < 0 || selectedIdx >= len(m.history.Jobs) {
+		return nil
+	}
+	return m.history.Jobs[selectedIdx]
+}
+
 // GetChoice returns the selected job, if any
 func (m *Model) GetChoice() *entity.Job {
 	return m.choice
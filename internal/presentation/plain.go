@@ -0,0 +1,48 @@
+package presentation
+
+import (
+	"io"
+	"strings"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/mattn/go-runewidth"
+)
+
+// writePlainTable writes the history as a static, column-aligned table without escape codes.
+// It is used instead of the interactive UI when stdout is not a terminal.
+func writePlainTable(w io.Writer, history *usecase.RunnerJobHistory, terminalWidth int) error {
+	showRunner := history.IsMultiRunner()
+	columns := getCalculatedColumnWidths(terminalWidth, showRunner)
+
+	var b strings.Builder
+	b.WriteString(renderHeader(history, terminalWidth))
+	b.WriteString("\n")
+
+	header := make(table.Row, len(columns))
+	for i, col := range columns {
+		header[i] = col.Title
+	}
+	writePlainRow(&b, columns, header)
+
+	for _, row := range buildRows(history.Jobs, showRunner) {
+		writePlainRow(&b, columns, row)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writePlainRow pads or truncates each cell to its column width, matching the interactive table layout
+func writePlainRow(b *strings.Builder, columns []table.Column, row table.Row) {
+	cells := make([]string, len(columns))
+	for i, col := range columns {
+		value := ""
+		if i < len(row) {
+			value = row[i]
+		}
+		cells[i] = runewidth.FillRight(runewidth.Truncate(value, col.Width, "…"), col.Width)
+	}
+	b.WriteString(strings.TrimRight(strings.Join(cells, " "), " "))
+	b.WriteString("\n")
+}
@@ -0,0 +1,81 @@
+package presentation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestWritePlainTable(t *testing.T) {
+	started := time.Date(2025, 11, 16, 1, 0, 0, 0, time.UTC)
+	completed := started.Add(4 * time.Minute)
+	history := &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: 1, Name: "runner-a", Status: "online", OS: "linux", Labels: []string{"self-hosted"}}},
+		Jobs: []*entity.Job{
+			{
+				ID:           1,
+				Name:         "a job name that is much longer than the minimum column width",
+				WorkflowName: "CI",
+				RunAttempt:   1,
+				Status:       entity.StatusCompleted,
+				Conclusion:   "success",
+				StartedAt:    &started,
+				CompletedAt:  &completed,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writePlainTable(&buf, history, 0); err != nil {
+		t.Fatalf("writePlainTable error: %v", err)
+	}
+	output := buf.String()
+
+	if strings.Contains(output, "\x1b[") {
+		t.Errorf("expected no escape codes in plain output:\n%s", output)
+	}
+	if !strings.HasPrefix(output, "Runner: runner-a\n") {
+		t.Errorf("expected runner header, got:\n%s", output)
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	headerLine := lines[len(lines)-2]
+	jobLine := lines[len(lines)-1]
+
+	// Columns line up between header and rows
+	if displayIndex(headerLine, "Status") != displayIndex(jobLine, "completed") {
+		t.Errorf("columns are not aligned:\n%s\n%s", headerLine, jobLine)
+	}
+	// Long values are truncated to the calculated column width
+	if !strings.Contains(jobLine, "…") {
+		t.Errorf("expected long job name to be truncated: %s", jobLine)
+	}
+	if width := runewidth.StringWidth(jobLine); width > defaultTerminalWidth {
+		t.Errorf("row width %d exceeds terminal width %d", width, defaultTerminalWidth)
+	}
+}
+
+// displayIndex returns the display column at which substr starts in s
+func displayIndex(s, substr string) int {
+	return runewidth.StringWidth(s[:strings.Index(s, substr)])
+}
+
+func TestRenderHeader_FitsRunnerListToWidth(t *testing.T) {
+	history := &usecase.RunnerJobHistory{}
+	for _, name := range []string{"runner-a", "runner-b", "runner-c", "runner-d", "runner-e", "runner-f", "runner-g"} {
+		history.Runners = append(history.Runners, &entity.Runner{Name: name})
+	}
+
+	narrow := renderHeader(history, 40)
+	if width := runewidth.StringWidth(strings.TrimSuffix(narrow, "\n")); width != 40 {
+		t.Errorf("expected the runner list to be cut at 40 columns, got %d: %q", width, narrow)
+	}
+	if wide := renderHeader(history, 200); wide != "Runners (7): runner-a, runner-b, runner-c, runner-d, runner-e, runner-f, runner-g\n" {
+		t.Errorf("expected the whole runner list on a wide terminal, got %q", wide)
+	}
+}
@@ -0,0 +1,226 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxRegressionsShown is the number of regressed runs listed under each job
+const maxRegressionsShown = 5
+
+// regressionMarker flags regressed runs in the Duration column of the interactive table
+const regressionMarker = "▲"
+
+// regressionStyle highlights the regression summary in the interactive UI
+var regressionStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("203"))
+
+// defaultRegressionOptions are used to highlight regressions in the interactive UI
+// Only loaded jobs with a full baseline of earlier loaded runs are highlighted.
+var defaultRegressionOptions = usecase.RegressionOptions{
+	BaselineRuns: usecase.DefaultBaselineRuns,
+	Factor:       usecase.DefaultRegressionFactor,
+}
+
+// RunRegressions fetches every job of the selected runners since the given time and writes
+// the runs that took much longer than usual, or their JSON form with FormatJSON
+func (c *Controller) RunRegressions(ctx context.Context, selector usecase.RunnerSelector, historyOpts usecase.HistoryOptions, since time.Time, regressionOpts usecase.RegressionOptions) error {
+	switch c.opts.Format {
+	case FormatTUI, FormatJSON:
+	default:
+		return fmt.Errorf("unsupported output format %q for regressions", c.opts.Format)
+	}
+
+	report, err := c.runnerLogger.FetchDurationRegressions(ctx, selector, historyOpts, since, regressionOpts)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.Format == FormatJSON {
+		err = writeDocument(c.opts, newJSONRegressions(report))
+	} else {
+		err = writeRegressionReport(c.opts.Out, report, terminalWidth())
+	}
+	if err != nil {
+		return err
+	}
+
+	if report.History.IsPartial() {
+		writeFailureSummary(c.opts.ErrOut, report.History.Failures)
+		return fmt.Errorf("regression report is incomplete: %s", describeFailures(report.History.Failures))
+	}
+	return nil
+}
+
+// jsonRegressions is the JSON document written by the regressions subcommand with --json
+type jsonRegressions struct {
+	Runner        jsonRunner           `json:"runner"`
+	Runners       []*jsonRunner        `json:"runners,omitempty"`
+	RunnerPattern string               `json:"runner_pattern,omitempty"`
+	From          time.Time            `json:"from"`
+	To            time.Time            `json:"to"`
+	BaselineRuns  int                  `json:"baseline_runs"`
+	Factor        float64              `json:"factor"`
+	Recent        *time.Time           `json:"recent"`
+	Jobs          []jsonJobRegressions `json:"jobs"`
+	Failures      []jsonFailure        `json:"failures,omitempty"`
+}
+
+// jsonJobRegressions is the JSON form of usecase.JobRegressions
+type jsonJobRegressions struct {
+	Repository      string                   `json:"repository"`
+	WorkflowName    string                   `json:"workflow_name"`
+	Name            string                   `json:"name"`
+	Runs            int                      `json:"runs"`
+	BaselineSeconds float64                  `json:"baseline_seconds"`
+	Regressions     []jsonDurationRegression `json:"regressions"`
+}
+
+// jsonDurationRegression is the JSON form of usecase.DurationRegression
+type jsonDurationRegression struct {
+	Job             jsonJob `json:"job"`
+	BaselineSeconds float64 `json:"baseline_seconds"`
+	Ratio           float64 `json:"ratio"`
+}
+
+// newJSONRegressions converts a regression report into its JSON document
+func newJSONRegressions(report *usecase.RegressionReport) jsonRegressions {
+	history := newJSONHistory(report.History)
+
+	jobs := make([]jsonJobRegressions, 0, len(report.Jobs))
+	for _, job := range report.Jobs {
+		regressions := make([]jsonDurationRegression, 0, len(job.Regressions))
+		for _, regression := range job.Regressions {
+			regressions = append(regressions, jsonDurationRegression{
+				Job:             newJSONJob(regression.Job),
+				BaselineSeconds: regression.Baseline.Seconds(),
+				Ratio:           regression.Ratio(),
+			})
+		}
+		jobs = append(jobs, jsonJobRegressions{
+			Repository:      job.Repository,
+			WorkflowName:    job.WorkflowName,
+			Name:            job.Name,
+			Runs:            job.Runs,
+			BaselineSeconds: job.Baseline.Seconds(),
+			Regressions:     regressions,
+		})
+	}
+
+	var recent *time.Time
+	if !report.Options.Recent.IsZero() {
+		recent = &report.Options.Recent
+	}
+
+	return jsonRegressions{
+		Runner:        history.Runner,
+		Runners:       history.Runners,
+		RunnerPattern: history.RunnerPattern,
+		From:          report.From,
+		To:            report.To,
+		BaselineRuns:  report.Options.BaselineRuns,
+		Factor:        report.Options.Factor,
+		Recent:        recent,
+		Jobs:          jobs,
+		Failures:      history.Failures,
+	}
+}
+
+// writeRegressionReport writes the runner header, how the baseline is computed and the jobs
+// with regressed runs
+func writeRegressionReport(w io.Writer, report *usecase.RegressionReport, terminalWidth int) error {
+	var b strings.Builder
+	b.WriteString(renderHeader(report.History, terminalWidth))
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Window:    %s → %s (%s)\n", formatTime(&report.From), formatTime(&report.To), formatDuration(report.To.Sub(report.From)))
+	fmt.Fprintf(&b, "Baseline:  median of the previous %d successful runs of each job\n", report.Options.BaselineRuns)
+	fmt.Fprintf(&b, "Threshold: %s the baseline\n", formatRatio(report.Options.Factor))
+	if !report.Options.Recent.IsZero() {
+		fmt.Fprintf(&b, "Flagged:   runs since %s\n", formatTime(&report.Options.Recent))
+	}
+
+	b.WriteString("\nRegressions:\n")
+	if len(report.Jobs) == 0 {
+		b.WriteString("  -\n")
+	}
+	for _, job := range report.Jobs {
+		fmt.Fprintf(&b, "  %s  %s / %s  %d of %d runs regressed, baseline now %s\n",
+			job.Repository,
+			job.WorkflowName,
+			job.Name,
+			len(job.Regressions),
+			job.Runs,
+			formatDuration(job.Baseline),
+		)
+		for i, regression := range job.Regressions {
+			if i == maxRegressionsShown {
+				fmt.Fprintf(&b, "    … %d more\n", len(job.Regressions)-maxRegressionsShown)
+				break
+			}
+			fmt.Fprintf(&b, "    %s  %s  %s baseline %s  on %s  run %d\n",
+				formatTime(regression.Job.StartedAt),
+				formatDuration(regression.Job.GetExecutionDuration()),
+				formatRatio(regression.Ratio()),
+				formatDuration(regression.Baseline),
+				formatRunnerName(regression.Job),
+				regression.Job.RunID,
+			)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatRatio formats how many times longer than the baseline a run took
+func formatRatio(ratio float64) string {
+	return fmt.Sprintf("%.1f×", ratio)
+}
+
+// markRegressions appends the regression marker and ratio to the Duration cell of regressed jobs
+// Rows must be built from jobs, with Duration as the last column.
+func markRegressions(rows []table.Row, jobs []*entity.Job, regressions []*usecase.JobRegressions) {
+	ratios := make(map[*entity.Job]float64)
+	for _, job := range regressions {
+		for _, regression := range job.Regressions {
+			ratios[regression.Job] = regression.Ratio()
+		}
+	}
+
+	for i, job := range jobs {
+		if ratio, ok := ratios[job]; ok {
+			last := len(rows[i]) - 1
+			rows[i][last] += " " + regressionMarker + formatRatio(ratio)
+		}
+	}
+}
+
+// countRegressions counts the regressed runs over every job
+func countRegressions(regressions []*usecase.JobRegressions) int {
+	count := 0
+	for _, job := range regressions {
+		count += len(job.Regressions)
+	}
+	return count
+}
+
+// renderRegressionBanner summarizes the regressed runs marked in the interactive table
+func renderRegressionBanner(regressions []*usecase.JobRegressions, opts usecase.RegressionOptions) string {
+	count := countRegressions(regressions)
+	if count == 0 {
+		return ""
+	}
+	runs := "runs"
+	if count == 1 {
+		runs = "run"
+	}
+	return regressionStyle.Render(fmt.Sprintf("%s %d %s took over %s the median of the previous %d runs of the same job",
+		regressionMarker, count, runs, formatRatio(opts.Factor), opts.BaselineRuns)) + "\n"
+}
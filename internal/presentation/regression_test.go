@@ -0,0 +1,160 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/charmbracelet/bubbles/table"
+)
+
+func TestWriteRegressionReport(t *testing.T) {
+	runner := "runner-a"
+	started8, started5 := time.Date(2025, 11, 16, 8, 0, 0, 0, time.UTC), time.Date(2025, 11, 16, 5, 0, 0, 0, time.UTC)
+	completed8, completed5 := started8.Add(9*time.Minute), started5.Add(6*time.Minute)
+	from := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+	report := &usecase.RegressionReport{
+		History: &usecase.RunnerJobHistory{
+			Runners: []*entity.Runner{{ID: 1, Name: "runner-a", Status: "online", OS: "linux"}},
+		},
+		From:    from,
+		To:      from.Add(7 * 24 * time.Hour),
+		Options: usecase.RegressionOptions{BaselineRuns: 10, Factor: 1.5},
+		Jobs: []*usecase.JobRegressions{
+			{
+				Repository:   "owner/repo",
+				WorkflowName: "CI",
+				Name:         "build",
+				Runs:         30,
+				Baseline:     6 * time.Minute,
+				Regressions: []usecase.DurationRegression{
+					{Job: &entity.Job{ID: 80, RunID: 8, RunnerName: &runner, StartedAt: &started8, CompletedAt: &completed8}, Baseline: 5 * time.Minute},
+					{Job: &entity.Job{ID: 50, RunID: 5, RunnerName: &runner, StartedAt: &started5, CompletedAt: &completed5}, Baseline: 4 * time.Minute},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeRegressionReport(&buf, report, defaultTerminalWidth); err != nil {
+		t.Fatalf("writeRegressionReport error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	// Everything after the runner header and the window
+	expected := []string{
+		"Baseline:  median of the previous 10 successful runs of each job",
+		"Threshold: 1.5× the baseline",
+		"",
+		"Regressions:",
+		"  owner/repo  CI / build  2 of 30 runs regressed, baseline now 6m 0s",
+		"    " + formatTime(&started8) + "  9m 0s  1.8× baseline 5m 0s  on runner-a  run 8",
+		"    " + formatTime(&started5) + "  6m 0s  1.5× baseline 4m 0s  on runner-a  run 5",
+	}
+	if len(lines) != 6+len(expected) {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+	for i := range expected {
+		if lines[6+i] != expected[i] {
+			t.Errorf("line %d = %q, want %q", 6+i, lines[6+i], expected[i])
+		}
+	}
+}
+
+func TestWriteRegressionReport_NoRegressions(t *testing.T) {
+	report := &usecase.RegressionReport{
+		History: &usecase.RunnerJobHistory{Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}}},
+		Options: usecase.RegressionOptions{BaselineRuns: 10, Factor: 1.5},
+	}
+
+	var buf bytes.Buffer
+	if err := writeRegressionReport(&buf, report, defaultTerminalWidth); err != nil {
+		t.Fatalf("writeRegressionReport error: %v", err)
+	}
+	if lines := strings.Split(buf.String(), "\n"); lines[9] != "Regressions:" || lines[10] != "  -" {
+		t.Errorf("expected a placeholder without regressions:\n%s", buf.String())
+	}
+}
+
+func TestNewJSONRegressions(t *testing.T) {
+	started := time.Date(2025, 11, 16, 8, 0, 0, 0, time.UTC)
+	completed := started.Add(9 * time.Minute)
+	report := &usecase.RegressionReport{
+		History: &usecase.RunnerJobHistory{Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}}},
+		Options: usecase.RegressionOptions{BaselineRuns: 10, Factor: 1.5},
+		Jobs: []*usecase.JobRegressions{
+			{
+				WorkflowName: "CI",
+				Name:         "build",
+				Runs:         30,
+				Baseline:     6 * time.Minute,
+				Regressions: []usecase.DurationRegression{
+					{Job: &entity.Job{ID: 80, RunID: 8, StartedAt: &started, CompletedAt: &completed}, Baseline: 5 * time.Minute},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, newJSONRegressions(report)); err != nil {
+		t.Fatalf("writeJSON error: %v", err)
+	}
+
+	var decoded struct {
+		Runner struct {
+			Name string `json:"name"`
+		} `json:"runner"`
+		BaselineRuns int     `json:"baseline_runs"`
+		Factor       float64 `json:"factor"`
+		Jobs         []struct {
+			Name            string  `json:"name"`
+			BaselineSeconds float64 `json:"baseline_seconds"`
+			Regressions     []struct {
+				Job struct {
+					ID int64 `json:"id"`
+				} `json:"job"`
+				BaselineSeconds float64 `json:"baseline_seconds"`
+				Ratio           float64 `json:"ratio"`
+			} `json:"regressions"`
+		} `json:"jobs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if decoded.Runner.Name != "runner-a" || decoded.BaselineRuns != 10 || decoded.Factor != 1.5 || len(decoded.Jobs) != 1 {
+		t.Fatalf("unexpected document: %s", buf.String())
+	}
+	build := decoded.Jobs[0]
+	if build.Name != "build" || build.BaselineSeconds != 360 || len(build.Regressions) != 1 {
+		t.Fatalf("unexpected job: %s", buf.String())
+	}
+	if r := build.Regressions[0]; r.Job.ID != 80 || r.BaselineSeconds != 300 || r.Ratio != 1.8 {
+		t.Errorf("unexpected regression: %+v", r)
+	}
+}
+
+func TestMarkRegressions(t *testing.T) {
+	started := time.Date(2025, 11, 16, 8, 0, 0, 0, time.UTC)
+	completed := started.Add(9 * time.Minute)
+	regressed := &entity.Job{ID: 80, RunID: 8, StartedAt: &started, CompletedAt: &completed}
+	other := &entity.Job{ID: 90, RunID: 9}
+	jobs := []*entity.Job{other, regressed}
+	rows := []table.Row{{"CI", "build", "5m 0s"}, {"CI", "build", "9m 0s"}}
+	regressions := []*usecase.JobRegressions{
+		{Regressions: []usecase.DurationRegression{{Job: regressed, Baseline: 5 * time.Minute}}},
+	}
+
+	markRegressions(rows, jobs, regressions)
+
+	if rows[0][2] != "5m 0s" {
+		t.Errorf("expected the other job to be unmarked, got %q", rows[0][2])
+	}
+	if rows[1][2] != "9m 0s ▲1.8×" {
+		t.Errorf("expected the regressed job to be marked, got %q", rows[1][2])
+	}
+}
@@ -0,0 +1,198 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+// RunStats fetches every job of the selected runners since the given time and writes
+// a statistics report, or its JSON form with FormatJSON
+func (c *Controller) RunStats(ctx context.Context, selector usecase.RunnerSelector, historyOpts usecase.HistoryOptions, since time.Time) error {
+	switch c.opts.Format {
+	case FormatTUI, FormatJSON:
+	default:
+		return fmt.Errorf("unsupported output format %q for statistics", c.opts.Format)
+	}
+
+	report, err := c.runnerLogger.FetchRunnerStats(ctx, selector, historyOpts, since)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.Format == FormatJSON {
+		err = writeDocument(c.opts, newJSONStats(report))
+	} else {
+		err = writeStatsReport(c.opts.Out, report, terminalWidth())
+	}
+	if err != nil {
+		return err
+	}
+
+	if report.History.IsPartial() {
+		writeFailureSummary(c.opts.ErrOut, report.History.Failures)
+		return fmt.Errorf("statistics are incomplete: %s", describeFailures(report.History.Failures))
+	}
+	return nil
+}
+
+// jsonStats is the JSON document written by the stats subcommand with --json
+type jsonStats struct {
+	Runner        jsonRunner       `json:"runner"`
+	Runners       []*jsonRunner    `json:"runners,omitempty"`
+	RunnerPattern string           `json:"runner_pattern,omitempty"`
+	From          time.Time        `json:"from"`
+	To            time.Time        `json:"to"`
+	TotalJobs     int              `json:"total_jobs"`
+	CompletedJobs int              `json:"completed_jobs"`
+	Conclusions   map[string]int   `json:"conclusions"`
+	SuccessRate   *float64         `json:"success_rate"`
+	FailureRate   *float64         `json:"failure_rate"`
+	Duration      jsonDurationPcts `json:"duration_seconds"`
+	QueueTime     jsonDurationPcts `json:"queue_seconds"`
+	JobsPerHour   float64          `json:"jobs_per_hour"`
+	JobsPerDay    float64          `json:"jobs_per_day"`
+	Failures      []jsonFailure    `json:"failures,omitempty"`
+}
+
+// jsonDurationPcts is the JSON form of usecase.DurationPercentiles, in seconds
+type jsonDurationPcts struct {
+	Count int     `json:"count"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P99   float64 `json:"p99"`
+}
+
+// newJSONStats converts the statistics report into its JSON representation
+func newJSONStats(report *usecase.RunnerStats) jsonStats {
+	history := newJSONHistory(report.History)
+	stats := report.Stats
+
+	doc := jsonStats{
+		Runner:        history.Runner,
+		Runners:       history.Runners,
+		RunnerPattern: history.RunnerPattern,
+		From:          stats.From,
+		To:            stats.To,
+		TotalJobs:     stats.Total,
+		CompletedJobs: stats.Completed,
+		Conclusions:   stats.Conclusions,
+		Duration:      newJSONDurationPcts(stats.Durations),
+		QueueTime:     newJSONDurationPcts(stats.QueueTimes),
+		JobsPerHour:   stats.JobsPerHour(),
+		JobsPerDay:    stats.JobsPerDay(),
+		Failures:      history.Failures,
+	}
+	if rate, ok := stats.SuccessRate(); ok {
+		doc.SuccessRate = &rate
+	}
+	if rate, ok := stats.FailureRate(); ok {
+		doc.FailureRate = &rate
+	}
+	return doc
+}
+
+// newJSONDurationPcts converts duration percentiles into their JSON representation
+func newJSONDurationPcts(p usecase.DurationPercentiles) jsonDurationPcts {
+	return jsonDurationPcts{
+		Count: p.Count,
+		P50:   p.P50.Seconds(),
+		P90:   p.P90.Seconds(),
+		P99:   p.P99.Seconds(),
+	}
+}
+
+// writeStatsReport writes the runner header followed by the statistics
+func writeStatsReport(w io.Writer, report *usecase.RunnerStats, terminalWidth int) error {
+	var b strings.Builder
+	b.WriteString(renderHeader(report.History, terminalWidth))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Window:        %s → %s (%s)\n",
+		formatTime(&report.Stats.From),
+		formatTime(&report.Stats.To),
+		formatDuration(report.Stats.To.Sub(report.Stats.From)),
+	))
+	b.WriteString(renderStats(report.Stats))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderStats renders job statistics as aligned "label: value" lines
+func renderStats(stats *usecase.JobStats) string {
+	successRate, hasSuccessRate := stats.SuccessRate()
+	failureRate, hasFailureRate := stats.FailureRate()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Jobs:          %d (%d completed, %d unfinished)\n", stats.Total, stats.Completed, stats.Total-stats.Completed)
+	fmt.Fprintf(&b, "Conclusions:   %s\n", formatConclusionCounts(stats.Conclusions))
+	fmt.Fprintf(&b, "Success rate:  %s\n", formatRate(successRate, hasSuccessRate))
+	fmt.Fprintf(&b, "Failure rate:  %s\n", formatRate(failureRate, hasFailureRate))
+	fmt.Fprintf(&b, "Duration:      %s\n", formatPercentiles(stats.Durations))
+	fmt.Fprintf(&b, "Queue time:    %s\n", formatPercentiles(stats.QueueTimes))
+	fmt.Fprintf(&b, "Throughput:    %.2f jobs/hour, %.1f jobs/day\n", stats.JobsPerHour(), stats.JobsPerDay())
+	return b.String()
+}
+
+// formatConclusionCounts lists conclusions from most to least frequent
+func formatConclusionCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "-"
+	}
+
+	conclusions := make([]string, 0, len(counts))
+	for conclusion := range counts {
+		conclusions = append(conclusions, conclusion)
+	}
+	sort.Slice(conclusions, func(i, j int) bool {
+		if counts[conclusions[i]] != counts[conclusions[j]] {
+			return counts[conclusions[i]] > counts[conclusions[j]]
+		}
+		return conclusions[i] < conclusions[j]
+	})
+
+	parts := make([]string, len(conclusions))
+	for i, conclusion := range conclusions {
+		parts[i] = fmt.Sprintf("%s %d", conclusion, counts[conclusion])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatRate formats a fraction as a percentage, or "-" when it is not available
+func formatRate(rate float64, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
+
+// formatPercentiles formats execution time percentiles
+func formatPercentiles(p usecase.DurationPercentiles) string {
+	if p.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("p50 %s, p90 %s, p99 %s (%d jobs)",
+		formatDuration(p.P50),
+		formatDuration(p.P90),
+		formatDuration(p.P99),
+		p.Count,
+	)
+}
+
+// computeLoadedJobStats computes statistics over the jobs shown in the interactive UI,
+// from the earliest start time until now
+func computeLoadedJobStats(jobs []*entity.Job, now time.Time) *usecase.JobStats {
+	from := now
+	for _, job := range jobs {
+		if job.StartedAt != nil && job.StartedAt.Before(from) {
+			from = *job.StartedAt
+		}
+	}
+	return usecase.ComputeJobStats(jobs, from, now)
+}
@@ -0,0 +1,123 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+func TestRenderStats(t *testing.T) {
+	from := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	created := from.Add(time.Hour - 30*time.Second)
+	started := from.Add(time.Hour)
+	end1, end2, end3 := started.Add(2*time.Minute), started.Add(4*time.Minute), started.Add(90*time.Second)
+	jobs := []*entity.Job{
+		{ID: 1, Status: entity.StatusCompleted, Conclusion: "success", CreatedAt: &created, StartedAt: &started, CompletedAt: &end1},
+		{ID: 2, Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &end2},
+		{ID: 3, Status: entity.StatusCompleted, Conclusion: "failure", StartedAt: &started, CompletedAt: &end3},
+		{ID: 4, Status: entity.StatusInProgress, StartedAt: &started},
+	}
+
+	output := renderStats(usecase.ComputeJobStats(jobs, from, from.Add(24*time.Hour)))
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+	expected := []string{
+		"Jobs:          4 (3 completed, 1 unfinished)",
+		"Conclusions:   success 2, failure 1",
+		"Success rate:  66.7%",
+		"Failure rate:  33.3%",
+		"Duration:      p50 2m 0s, p90 4m 0s, p99 4m 0s (3 jobs)",
+		"Queue time:    p50 30s, p90 30s, p99 30s (1 jobs)",
+		"Throughput:    0.17 jobs/hour, 4.0 jobs/day",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got:\n%s", len(expected), output)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], expected[i])
+		}
+	}
+}
+
+func TestRenderStats_NoJobs(t *testing.T) {
+	now := time.Now()
+	lines := strings.Split(renderStats(usecase.ComputeJobStats(nil, now.Add(-time.Hour), now)), "\n")
+
+	if lines[2] != "Success rate:  -" || lines[4] != "Duration:      -" {
+		t.Errorf("expected placeholders without jobs, got %q and %q", lines[2], lines[4])
+	}
+}
+
+func TestRenderStatsPanel_LabelsLoadedJobs(t *testing.T) {
+	now := time.Date(2025, 11, 15, 12, 0, 0, 0, time.UTC)
+	first, second := now.Add(-3*time.Hour), now.Add(-time.Hour)
+	jobs := []*entity.Job{
+		{ID: 2, Status: entity.StatusInProgress, StartedAt: &second},
+		{ID: 1, Status: entity.StatusInProgress, StartedAt: &first},
+		{ID: 3, Status: entity.StatusQueued},
+	}
+
+	lines := strings.Split(renderStatsPanel(computeLoadedJobStats(jobs, now)), "\n")
+
+	since := first.Local().Format("2006-01-02 15:04")
+	if expected := "Statistics for the 3 loaded jobs (--max-count) since " + since; lines[1] != expected {
+		t.Errorf("panel title = %q, want %q", lines[1], expected)
+	}
+}
+
+func TestNewJSONStats(t *testing.T) {
+	from := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	started := from.Add(time.Hour)
+	end1, end2, end3 := started.Add(2*time.Minute), started.Add(4*time.Minute), started.Add(90*time.Second)
+	jobs := []*entity.Job{
+		{ID: 1, Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &end1},
+		{ID: 2, Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &end2},
+		{ID: 3, Status: entity.StatusCompleted, Conclusion: "failure", StartedAt: &started, CompletedAt: &end3},
+		{ID: 4, Status: entity.StatusInProgress, StartedAt: &started},
+	}
+	report := &usecase.RunnerStats{
+		History: &usecase.RunnerJobHistory{
+			Runners: []*entity.Runner{{ID: 1, Name: "runner-a", Status: "online", OS: "linux"}},
+			Jobs:    jobs,
+		},
+		Stats: usecase.ComputeJobStats(jobs, from, from.Add(24*time.Hour)),
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, newJSONStats(report)); err != nil {
+		t.Fatalf("writeJSON error: %v", err)
+	}
+
+	var decoded struct {
+		Runner      *jsonRunner    `json:"runner"`
+		TotalJobs   int            `json:"total_jobs"`
+		Conclusions map[string]int `json:"conclusions"`
+		SuccessRate *float64       `json:"success_rate"`
+		Duration    struct {
+			P50 float64 `json:"p50"`
+		} `json:"duration_seconds"`
+		JobsPerDay float64 `json:"jobs_per_day"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if decoded.Runner == nil || decoded.Runner.Name != "runner-a" {
+		t.Errorf("unexpected runner: %+v", decoded.Runner)
+	}
+	if decoded.TotalJobs != 4 || decoded.Conclusions["success"] != 2 || decoded.JobsPerDay != 4 {
+		t.Errorf("unexpected stats: %+v", decoded)
+	}
+	if decoded.SuccessRate == nil || *decoded.SuccessRate < 0.66 || *decoded.SuccessRate > 0.67 {
+		t.Errorf("unexpected success rate: %v", decoded.SuccessRate)
+	}
+	if decoded.Duration.P50 != 120 {
+		t.Errorf("expected p50 of 120 seconds, got %v", decoded.Duration.P50)
+	}
+}
@@ -0,0 +1,311 @@
+package presentation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// Timeline layout constants
+const (
+	maxTimelineZoom       = 256
+	maxTimelineLabelWidth = 24
+	minTimelineBarWidth   = 10
+	// timelineTickSpacing is the minimum number of cells between two axis labels
+	timelineTickSpacing = 16
+)
+
+// Timeline styles, one per kind of job outcome
+var (
+	timelineSuccessStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	timelineFailureStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	timelineCancelledStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	timelineSkippedStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	timelineInProgressStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	timelineOtherStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	timelineIdleStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
+	timelineSelectedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+)
+
+// timelineLane is a row of the timeline holding jobs that do not overlap
+type timelineLane struct {
+	// label is the runner name on the first lane of a runner and empty on the lanes below it
+	label string
+	jobs  []*entity.Job
+}
+
+// timeline draws the loaded jobs on a horizontal time axis, one or more lanes per runner
+type timeline struct {
+	lanes []timelineLane
+	from  time.Time
+	to    time.Time
+	// zoom divides the whole window; 1 shows everything
+	zoom int
+	// offset is the start of the visible part, relative to from
+	offset time.Duration
+}
+
+// newTimeline lays out the started jobs between from, the start of the --since window, and now
+// The window is extended to jobs that started before from; a zero from starts it at the
+// earliest job. Jobs still running extend until now.
+func newTimeline(jobs []*entity.Job, from, now time.Time) *timeline {
+	t := &timeline{from: now, to: now, zoom: 1}
+	if !from.IsZero() && from.Before(now) {
+		t.from = from
+	}
+	for _, job := range jobs {
+		if job.StartedAt != nil && job.StartedAt.Before(t.from) {
+			t.from = *job.StartedAt
+		}
+	}
+	t.lanes = buildTimelineLanes(jobs, now)
+	return t
+}
+
+// buildTimelineLanes groups the started jobs by runner, sorted by name, and spreads the jobs
+// of a runner over as many lanes as needed so that jobs in a lane never overlap
+func buildTimelineLanes(jobs []*entity.Job, now time.Time) []timelineLane {
+	byRunner := make(map[string][]*entity.Job)
+	for _, job := range jobs {
+		if job.StartedAt == nil {
+			continue
+		}
+		name := formatRunnerName(job)
+		byRunner[name] = append(byRunner[name], job)
+	}
+
+	names := make([]string, 0, len(byRunner))
+	for name := range byRunner {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lanes []timelineLane
+	for _, name := range names {
+		runnerJobs := byRunner[name]
+		sort.SliceStable(runnerJobs, func(i, j int) bool {
+			return runnerJobs[i].StartedAt.Before(*runnerJobs[j].StartedAt)
+		})
+
+		// First fit: put each job on the first lane whose last job has already ended
+		var runnerLanes []timelineLane
+		var laneEnds []time.Time
+		for _, job := range runnerJobs {
+			lane := -1
+			for i, end := range laneEnds {
+				if !job.StartedAt.Before(end) {
+					lane = i
+					break
+				}
+			}
+			if lane < 0 {
+				runnerLanes = append(runnerLanes, timelineLane{})
+				laneEnds = append(laneEnds, time.Time{})
+				lane = len(runnerLanes) - 1
+			}
+			runnerLanes[lane].jobs = append(runnerLanes[lane].jobs, job)
+			laneEnds[lane] = timelineJobEnd(job, now)
+		}
+
+		runnerLanes[0].label = name
+		lanes = append(lanes, runnerLanes...)
+	}
+	return lanes
+}
+
+// timelineJobEnd returns when the job completed, or now for jobs still running
+func timelineJobEnd(job *entity.Job, now time.Time) time.Time {
+	if job.CompletedAt != nil {
+		return *job.CompletedAt
+	}
+	return now
+}
+
+// span returns the length of the whole window
+func (t *timeline) span() time.Duration {
+	return t.to.Sub(t.from)
+}
+
+// visibleWindow returns the part of the window currently shown
+func (t *timeline) visibleWindow() (time.Time, time.Time) {
+	from := t.from.Add(t.offset)
+	return from, from.Add(t.span() / time.Duration(t.zoom))
+}
+
+// zoomIn halves the visible part, keeping its center in place
+func (t *timeline) zoomIn() {
+	t.setZoom(t.zoom * 2)
+}
+
+// zoomOut doubles the visible part, keeping its center in place
+func (t *timeline) zoomOut() {
+	t.setZoom(t.zoom / 2)
+}
+
+// setZoom changes the zoom level around the center of the visible part
+func (t *timeline) setZoom(zoom int) {
+	zoom = min(max(zoom, 1), maxTimelineZoom)
+	center := t.offset + t.span()/time.Duration(t.zoom)/2
+	t.zoom = zoom
+	t.offset = center - t.span()/time.Duration(t.zoom)/2
+	t.clampOffset()
+}
+
+// pan moves the visible part by a quarter of its width, to the right for positive steps
+func (t *timeline) pan(steps int) {
+	t.offset += time.Duration(steps) * t.span() / time.Duration(t.zoom) / 4
+	t.clampOffset()
+}
+
+// reset shows the whole window again
+func (t *timeline) reset() {
+	t.zoom = 1
+	t.offset = 0
+}
+
+// clampOffset keeps the visible part inside the window
+func (t *timeline) clampOffset() {
+	limit := t.span() - t.span()/time.Duration(t.zoom)
+	t.offset = min(max(t.offset, 0), limit)
+}
+
+// render draws the visible part of the timeline within the given size, highlighting the selected job
+func (t *timeline) render(selected *entity.Job, width, height int) string {
+	if len(t.lanes) == 0 || t.span() <= 0 {
+		return "No started jobs to draw.\n"
+	}
+
+	labelWidth := 0
+	for _, lane := range t.lanes {
+		labelWidth = max(labelWidth, runewidth.StringWidth(lane.label))
+	}
+	labelWidth = min(labelWidth, maxTimelineLabelWidth)
+	barWidth := max(width-labelWidth-1, minTimelineBarWidth)
+
+	from, to := t.visibleWindow()
+	cell := to.Sub(from) / time.Duration(barWidth)
+	if cell <= 0 {
+		cell = 1
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Timeline: %s → %s (zoom %dx)\n", formatTime(&from), formatTime(&to), t.zoom)
+
+	// Leave room for the title, the axis, the legend and the selected job
+	maxLanes := max(height-4, 1)
+	lanes := t.lanes
+	if len(lanes) > maxLanes {
+		lanes = lanes[:maxLanes-1]
+	}
+	for _, lane := range lanes {
+		b.WriteString(runewidth.FillRight(runewidth.Truncate(lane.label, labelWidth, "…"), labelWidth))
+		b.WriteString(" ")
+		b.WriteString(t.renderLane(lane, selected, from, to, cell, barWidth))
+		b.WriteString("\n")
+	}
+	if hidden := len(t.lanes) - len(lanes); hidden > 0 {
+		fmt.Fprintf(&b, "… %d more lanes\n", hidden)
+	}
+
+	b.WriteString(strings.Repeat(" ", labelWidth+1))
+	b.WriteString(renderTimelineAxis(from, to, cell, barWidth))
+	b.WriteString("\n")
+	b.WriteString(renderTimelineLegend())
+	b.WriteString("\n")
+	if selected != nil {
+		fmt.Fprintf(&b, "Selected: %s / %s (%s) %s, %s\n",
+			selected.WorkflowName,
+			selected.Name,
+			formatConclusion(selected),
+			formatTime(selected.StartedAt),
+			formatJobDuration(selected),
+		)
+	}
+	return b.String()
+}
+
+// renderLane draws the jobs of a lane that fall inside the visible part
+// Each job covers at least one cell so that short jobs stay visible when zoomed out.
+func (t *timeline) renderLane(lane timelineLane, selected *entity.Job, from, to time.Time, cell time.Duration, barWidth int) string {
+	cells := make([]*entity.Job, barWidth)
+	for _, job := range lane.jobs {
+		end := timelineJobEnd(job, t.to)
+		if !end.After(from) || !job.StartedAt.Before(to) {
+			continue
+		}
+		first := min(max(int(job.StartedAt.Sub(from)/cell), 0), barWidth-1)
+		last := min(max(int((end.Sub(from)-1)/cell), first), barWidth-1)
+		for c := first; c <= last; c++ {
+			cells[c] = job
+		}
+	}
+
+	var b strings.Builder
+	for _, job := range cells {
+		switch {
+		case job == nil:
+			b.WriteString(timelineIdleStyle.Render("·"))
+		case job == selected:
+			b.WriteString(timelineSelectedStyle.Render("▓"))
+		default:
+			b.WriteString(timelineJobStyle(job).Render("█"))
+		}
+	}
+	return b.String()
+}
+
+// renderTimelineAxis labels the time axis at regular intervals
+func renderTimelineAxis(from, to time.Time, cell time.Duration, barWidth int) string {
+	layout := "15:04"
+	if to.Sub(from) > 24*time.Hour {
+		layout = "01-02 15:04"
+	}
+
+	axis := []rune(strings.Repeat(" ", barWidth))
+	step := max(len(layout)+3, timelineTickSpacing)
+	for c := 0; c+len(layout)+1 <= barWidth; c += step {
+		label := []rune("└" + from.Add(time.Duration(c)*cell).Local().Format(layout))
+		copy(axis[c:], label)
+	}
+	return string(axis)
+}
+
+// renderTimelineLegend explains the colours and lists the timeline keys
+func renderTimelineLegend() string {
+	return strings.Join([]string{
+		timelineSuccessStyle.Render("█") + " success",
+		timelineFailureStyle.Render("█") + " failure",
+		timelineCancelledStyle.Render("█") + " cancelled",
+		timelineInProgressStyle.Render("█") + " running",
+		timelineSelectedStyle.Render("▓") + " selected",
+	}, "  ") + "   ←/→ pan  +/- zoom  0 reset  t table"
+}
+
+// timelineJobStyle picks the colour of a job from its status and conclusion
+func timelineJobStyle(job *entity.Job) lipgloss.Style {
+	return outcomeStyle(job.IsCompleted(), job.Conclusion)
+}
+
+// outcomeStyle returns the style of a job or step outcome, given whether it completed and its conclusion
+func outcomeStyle(completed bool, conclusion string) lipgloss.Style {
+	if !completed {
+		return timelineInProgressStyle
+	}
+	switch conclusion {
+	case entity.ConclusionSuccess:
+		return timelineSuccessStyle
+	case entity.ConclusionFailure, entity.ConclusionTimedOut:
+		return timelineFailureStyle
+	case entity.ConclusionCancelled:
+		return timelineCancelledStyle
+	case entity.ConclusionSkipped:
+		return timelineSkippedStyle
+	default:
+		return timelineOtherStyle
+	}
+}
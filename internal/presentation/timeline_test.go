@@ -0,0 +1,121 @@
+package presentation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+)
+
+func TestBuildTimelineLanes(t *testing.T) {
+	start := time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC)
+	runnerA, runnerB := "runner-a", "runner-b"
+	start3, start4 := start.Add(5*time.Minute), start.Add(10*time.Minute)
+	end1, end2, end3, end4 := start.Add(time.Hour), start.Add(10*time.Minute), start3.Add(10*time.Minute), start4.Add(time.Minute)
+	jobs := []*entity.Job{
+		{ID: 1, Status: entity.StatusCompleted, RunnerName: &runnerB, StartedAt: &start, CompletedAt: &end1},
+		{ID: 2, Status: entity.StatusCompleted, RunnerName: &runnerA, StartedAt: &start, CompletedAt: &end2},
+		// Overlaps job 2, so it needs a second lane
+		{ID: 3, Status: entity.StatusCompleted, RunnerName: &runnerA, StartedAt: &start3, CompletedAt: &end3},
+		// Starts when job 2 ends, so it fits on the first lane
+		{ID: 4, Status: entity.StatusCompleted, RunnerName: &runnerA, StartedAt: &start4, CompletedAt: &end4},
+		{ID: 5, Status: entity.StatusQueued},
+	}
+
+	lanes := buildTimelineLanes(jobs, start.Add(2*time.Hour))
+
+	if len(lanes) != 3 {
+		t.Fatalf("expected 3 lanes, got %d", len(lanes))
+	}
+	if lanes[0].label != "runner-a" || lanes[1].label != "" || lanes[2].label != "runner-b" {
+		t.Errorf("unexpected labels: %q, %q, %q", lanes[0].label, lanes[1].label, lanes[2].label)
+	}
+	if len(lanes[0].jobs) != 2 || lanes[0].jobs[1].ID != 4 || len(lanes[1].jobs) != 1 || lanes[1].jobs[0].ID != 3 {
+		t.Errorf("unexpected lane packing: %+v", lanes[:2])
+	}
+}
+
+func TestTimeline_ZoomAndPan(t *testing.T) {
+	start := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	job := &entity.Job{ID: 1, Status: entity.StatusCompleted, StartedAt: &start, CompletedAt: &end}
+	tl := newTimeline([]*entity.Job{job}, time.Time{}, start.Add(8*time.Hour))
+
+	tl.zoomIn()
+	from, to := tl.visibleWindow()
+	if !from.Equal(start.Add(2*time.Hour)) || !to.Equal(start.Add(6*time.Hour)) {
+		t.Errorf("zooming in should keep the center, got %v → %v", from, to)
+	}
+
+	tl.pan(-10)
+	if from, _ := tl.visibleWindow(); !from.Equal(start) {
+		t.Errorf("panning should stop at the start of the window, got %v", from)
+	}
+	tl.pan(10)
+	if _, to := tl.visibleWindow(); !to.Equal(start.Add(8 * time.Hour)) {
+		t.Errorf("panning should stop at the end of the window, got %v", to)
+	}
+
+	tl.zoomOut()
+	tl.zoomOut()
+	if from, to := tl.visibleWindow(); tl.zoom != 1 || !from.Equal(start) || !to.Equal(start.Add(8*time.Hour)) {
+		t.Errorf("zooming out should show the whole window, got zoom %d, %v → %v", tl.zoom, from, to)
+	}
+}
+
+func TestTimeline_Render(t *testing.T) {
+	start := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	runner := "runner-a"
+	end1, start2, end2 := start.Add(2*time.Hour), start.Add(6*time.Hour), start.Add(8*time.Hour)
+	first := &entity.Job{ID: 1, Status: entity.StatusCompleted, Conclusion: "success", RunnerName: &runner, StartedAt: &start, CompletedAt: &end1}
+	second := &entity.Job{
+		ID:           2,
+		Name:         "test",
+		WorkflowName: "CI",
+		Status:       entity.StatusCompleted,
+		Conclusion:   "failure",
+		RunnerName:   &runner,
+		StartedAt:    &start2,
+		CompletedAt:  &end2,
+	}
+	tl := newTimeline([]*entity.Job{first, second}, time.Time{}, start.Add(8*time.Hour))
+
+	output := tl.render(second, 9+40, 20)
+	lines := strings.Split(output, "\n")
+
+	lane := strings.TrimPrefix(lines[1], "runner-a ")
+	if want := strings.Repeat("█", 10) + strings.Repeat("·", 20) + strings.Repeat("▓", 10); lane != want {
+		t.Errorf("unexpected lane:\n%s\nwant:\n%s", lane, want)
+	}
+	if !strings.HasSuffix(lines[0], "(zoom 1x)") {
+		t.Errorf("expected the zoom level in the title, got %q", lines[0])
+	}
+	if selected := lines[len(lines)-2]; !strings.HasPrefix(selected, "Selected: CI / test (failure)") {
+		t.Errorf("unexpected selected job line: %q", selected)
+	}
+}
+
+func TestTimeline_RenderWithoutJobs(t *testing.T) {
+	tl := newTimeline([]*entity.Job{{ID: 1, Status: entity.StatusQueued}}, time.Time{}, time.Now())
+
+	if output := tl.render(nil, 80, 20); output != "No started jobs to draw.\n" {
+		t.Errorf("unexpected output: %q", output)
+	}
+}
+
+func TestNewTimeline_CoversSinceWindow(t *testing.T) {
+	start := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	since := start.Add(-14 * 24 * time.Hour)
+	now := start.Add(2 * time.Hour)
+	job := &entity.Job{ID: 1, Status: entity.StatusCompleted, StartedAt: &start, CompletedAt: &end}
+
+	if tl := newTimeline([]*entity.Job{job}, since, now); !tl.from.Equal(since) || !tl.to.Equal(now) {
+		t.Errorf("timeline should cover the --since window, got %v → %v", tl.from, tl.to)
+	}
+	// A job that started before the window still fits on the timeline
+	if tl := newTimeline([]*entity.Job{job}, start.Add(time.Hour), now); !tl.from.Equal(start) {
+		t.Errorf("timeline should extend to the earliest job, got %v", tl.from)
+	}
+}
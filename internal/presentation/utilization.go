@@ -0,0 +1,169 @@
+package presentation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/mattn/go-runewidth"
+)
+
+// utilizationBarWidth is the width of the bar drawn for each day
+const utilizationBarWidth = 20
+
+// RunUtilization fetches every job of the selected runners since the given time and writes
+// a utilization report, or its JSON form with FormatJSON
+func (c *Controller) RunUtilization(ctx context.Context, selector usecase.RunnerSelector, historyOpts usecase.HistoryOptions, since time.Time, maxGaps int) error {
+	switch c.opts.Format {
+	case FormatTUI, FormatJSON:
+	default:
+		return fmt.Errorf("unsupported output format %q for utilization", c.opts.Format)
+	}
+
+	report, err := c.runnerLogger.FetchRunnerUtilization(ctx, selector, historyOpts, since, maxGaps)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.Format == FormatJSON {
+		err = writeDocument(c.opts, newJSONUtilization(report))
+	} else {
+		err = writeUtilizationReport(c.opts.Out, report, terminalWidth())
+	}
+	if err != nil {
+		return err
+	}
+
+	if report.History.IsPartial() {
+		writeFailureSummary(c.opts.ErrOut, report.History.Failures)
+		return fmt.Errorf("utilization is incomplete: %s", describeFailures(report.History.Failures))
+	}
+	return nil
+}
+
+// jsonUtilization is the JSON document written by the utilization subcommand with --json
+type jsonUtilization struct {
+	Runner           jsonRunner       `json:"runner"`
+	Runners          []*jsonRunner    `json:"runners,omitempty"`
+	RunnerPattern    string           `json:"runner_pattern,omitempty"`
+	From             time.Time        `json:"from"`
+	To               time.Time        `json:"to"`
+	RunnerCount      int              `json:"runner_count"`
+	BusySeconds      float64          `json:"busy_seconds"`
+	AvailableSeconds float64          `json:"available_seconds"`
+	Utilization      float64          `json:"utilization_percent"`
+	Days             []jsonDailyUsage `json:"days"`
+	IdleGaps         []jsonIdleGap    `json:"idle_gaps"`
+	Failures         []jsonFailure    `json:"failures,omitempty"`
+}
+
+// jsonDailyUsage is the JSON form of usecase.DailyUtilization
+type jsonDailyUsage struct {
+	Date             string  `json:"date"`
+	BusySeconds      float64 `json:"busy_seconds"`
+	AvailableSeconds float64 `json:"available_seconds"`
+	Utilization      float64 `json:"utilization_percent"`
+}
+
+// jsonIdleGap is the JSON form of usecase.IdleGap
+type jsonIdleGap struct {
+	Runner          string    `json:"runner"`
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	DurationSeconds float64   `json:"duration_seconds"`
+}
+
+// newJSONUtilization converts the utilization report into its JSON representation
+func newJSONUtilization(report *usecase.RunnerUtilization) jsonUtilization {
+	history := newJSONHistory(report.History)
+	u := report.Utilization
+
+	days := make([]jsonDailyUsage, 0, len(u.Days))
+	for _, day := range u.Days {
+		days = append(days, jsonDailyUsage{
+			Date:             day.Date.Format("2006-01-02"),
+			BusySeconds:      day.Busy.Seconds(),
+			AvailableSeconds: day.Available.Seconds(),
+			Utilization:      day.Percent(),
+		})
+	}
+
+	gaps := make([]jsonIdleGap, 0, len(u.IdleGaps))
+	for _, gap := range u.IdleGaps {
+		gaps = append(gaps, jsonIdleGap{
+			Runner:          gap.Runner,
+			From:            gap.From,
+			To:              gap.To,
+			DurationSeconds: gap.Duration().Seconds(),
+		})
+	}
+
+	return jsonUtilization{
+		Runner:           history.Runner,
+		Runners:          history.Runners,
+		RunnerPattern:    history.RunnerPattern,
+		From:             u.From,
+		To:               u.To,
+		RunnerCount:      u.Runners,
+		BusySeconds:      u.Busy.Seconds(),
+		AvailableSeconds: u.Available().Seconds(),
+		Utilization:      u.Percent(),
+		Days:             days,
+		IdleGaps:         gaps,
+		Failures:         history.Failures,
+	}
+}
+
+// writeUtilizationReport writes the runner header, overall utilization, a per-day breakdown
+// and the largest idle gaps
+func writeUtilizationReport(w io.Writer, report *usecase.RunnerUtilization, terminalWidth int) error {
+	u := report.Utilization
+
+	var b strings.Builder
+	b.WriteString(renderHeader(report.History, terminalWidth))
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Window:        %s → %s (%s)\n", formatTime(&u.From), formatTime(&u.To), formatDuration(u.To.Sub(u.From)))
+	fmt.Fprintf(&b, "Runners:       %d\n", u.Runners)
+	fmt.Fprintf(&b, "Busy time:     %s of %s (%.1f%%)\n", formatDuration(u.Busy), formatDuration(u.Available()), u.Percent())
+
+	b.WriteString("\nUtilization per day:\n")
+	for _, day := range u.Days {
+		fmt.Fprintf(&b, "  %s  %s %5.1f%%  %s of %s\n",
+			day.Date.Format("2006-01-02 Mon"),
+			renderUtilizationBar(day.Percent()),
+			day.Percent(),
+			formatDuration(day.Busy),
+			formatDuration(day.Available),
+		)
+	}
+
+	b.WriteString("\nLargest idle gaps:\n")
+	if len(u.IdleGaps) == 0 {
+		b.WriteString("  -\n")
+	}
+	nameWidth := 0
+	for _, gap := range u.IdleGaps {
+		nameWidth = max(nameWidth, runewidth.StringWidth(gap.Runner))
+	}
+	for _, gap := range u.IdleGaps {
+		fmt.Fprintf(&b, "  %s  %s → %s  %s\n",
+			runewidth.FillRight(gap.Runner, nameWidth),
+			formatTime(&gap.From),
+			formatTime(&gap.To),
+			formatDuration(gap.Duration()),
+		)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderUtilizationBar draws a percentage as a fixed-width bar
+func renderUtilizationBar(percent float64) string {
+	filled := int(percent/100*utilizationBarWidth + 0.5)
+	filled = min(max(filled, 0), utilizationBarWidth)
+	return strings.Repeat("█", filled) + strings.Repeat("░", utilizationBarWidth-filled)
+}
@@ -0,0 +1,60 @@
+package presentation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+)
+
+func TestWriteUtilizationReport(t *testing.T) {
+	from := time.Date(2025, 11, 15, 0, 0, 0, 0, time.Local)
+	gapFrom, gapTo := from.Add(time.Hour), from.Add(4*time.Hour)
+	report := &usecase.RunnerUtilization{
+		History: &usecase.RunnerJobHistory{
+			Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}, {ID: 2, Name: "runner-b"}},
+		},
+		Utilization: &usecase.Utilization{
+			From:    from,
+			To:      from.Add(48 * time.Hour),
+			Runners: 2,
+			Busy:    24 * time.Hour,
+			Days: []usecase.DailyUtilization{
+				{Date: from, Busy: 12 * time.Hour, Available: 48 * time.Hour},
+				{Date: from.AddDate(0, 0, 1), Busy: 12 * time.Hour, Available: 48 * time.Hour},
+			},
+			IdleGaps: []usecase.IdleGap{
+				{Runner: "runner-a", From: gapFrom, To: gapTo},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeUtilizationReport(&buf, report, defaultTerminalWidth); err != nil {
+		t.Fatalf("writeUtilizationReport error: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{
+		"Runners (2): runner-a, runner-b\n",
+		"Busy time:     24h 0m of 96h 0m (25.0%)\n",
+		"  2025-11-15 Sat  █████░░░░░░░░░░░░░░░  25.0%  12h 0m of 48h 0m\n",
+		"  runner-a  " + formatTime(&gapFrom) + " → " + formatTime(&gapTo) + "  3h 0m\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, output)
+		}
+	}
+}
+
+func TestRenderUtilizationBar(t *testing.T) {
+	if bar := renderUtilizationBar(0); bar != strings.Repeat("░", utilizationBarWidth) {
+		t.Errorf("unexpected empty bar %q", bar)
+	}
+	if bar := renderUtilizationBar(120); bar != strings.Repeat("█", utilizationBarWidth) {
+		t.Errorf("expected full bar to be capped, got %q", bar)
+	}
+}
@@ -2,14 +2,24 @@ package presentation
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
 	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
+// warningStyle highlights the incomplete history banner
+var warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+
+// statsTitleStyle highlights the title of the statistics panel
+var statsTitleStyle = lipgloss.NewStyle().Bold(true)
+
 // View renders the model
 func (m *Model) View() string {
 	if m.quitting || m.err != nil {
@@ -20,52 +30,123 @@ func (m *Model) View() string {
 		return fmt.Sprintf("\n%s Loading runner job history...\n", m.spinner.View())
 	}
 
-	header := renderHeader(m.history)
-	return header + "\n" + m.table.View()
+	if m.logView != nil {
+		return m.logView.render(m.width, m.spinner.View())
+	}
+
+	header := renderHeader(m.history, m.width) + m.renderBanners()
+	if m.showStats {
+		header += renderStatsPanel(m.stats)
+	}
+	var body string
+	switch m.mode {
+	case viewTimeline:
+		// The table keeps handling the cursor keys, so the selected job can be followed on the timeline
+		body = m.timeline.render(m.selectedJob(), m.width, m.tableHeight())
+	case viewHeatmap:
+		return header + "\n" + renderHeatmapPanel(m.heatmap, m.heatmapMetric)
+	default:
+		body = m.table.View()
+	}
+	if m.showDetail {
+		if !strings.HasSuffix(body, "\n") {
+			body += "\n"
+		}
+		body += renderJobDetail(m.selectedJob(), m.width, time.Now())
+	}
+	return header + "\n" + body
 }
 
-// renderHeader renders the runner information header
-func renderHeader(history *usecase.RunnerJobHistory) string {
-	return fmt.Sprintf("Runner: %s\nStatus: %s\nOS: %s\nLabels: %s\n",
-		history.Runner.Name,
-		history.Runner.Status,
-		history.Runner.OS,
-		strings.Join(history.Runner.Labels, ", "),
-	)
+// renderBanners renders the lines shown below the runner header: the incomplete history warning,
+// the regression summary, the watch mode status and the notice about the previous key
+func (m *Model) renderBanners() string {
+	var banners string
+	if m.history.IsPartial() {
+		banners += warningStyle.Render("⚠ Incomplete history: "+describeFailures(m.history.Failures)) + "\n"
+	}
+	banners += renderRegressionBanner(m.regressions, defaultRegressionOptions)
+	banners += m.renderWatchStatus()
+	if m.notice != "" {
+		banners += warningStyle.Render("⚠ "+m.notice) + "\n"
+	}
+	return banners
 }
 
-// buildRows converts jobs to table rows
-func buildRows(jobs []*entity.Job) []table.Row {
-	rows := make([]table.Row, len(jobs))
-	for i, job := range jobs {
-		startedAt := "-"
-		if job.StartedAt != nil {
-			startedAt = job.StartedAt.Local().Format("2006-01-02 15:04:05 MST")
-		}
+// renderStatsPanel renders statistics over the loaded jobs for the interactive UI
+// The jobs are limited by --max-count, so the title says so: the stats subcommand covers every
+// job in the --since window and can report different figures.
+func renderStatsPanel(stats *usecase.JobStats) string {
+	title := fmt.Sprintf("Statistics for the %d loaded jobs (--max-count) since %s",
+		stats.Total, stats.From.Local().Format("2006-01-02 15:04"))
+	return "\n" + statsTitleStyle.Render(title) + "\n" + renderStats(stats)
+}
 
-		duration := "-"
-		if job.CompletedAt != nil && job.StartedAt != nil {
-			d := job.GetExecutionDuration()
-			duration = formatDuration(d)
-		} else if job.StartedAt != nil && job.Status == entity.StatusInProgress {
-			d := time.Since(*job.StartedAt)
-			duration = formatDuration(d) + " (running)"
-		}
+// renderHeader renders the runner information header, keeping the runner list within width
+func renderHeader(history *usecase.RunnerJobHistory, width int) string {
+	if history.RunnerPattern != "" {
+		return fmt.Sprintf("Runner pattern: %s\nMatched runners: %d\n",
+			history.RunnerPattern,
+			countRunners(history.Jobs),
+		)
+	}
+	if runner := history.SingleRunner(); runner != nil {
+		return fmt.Sprintf("Runner: %s\nStatus: %s\nOS: %s\nLabels: %s\n",
+			runner.Name,
+			runner.Status,
+			runner.OS,
+			strings.Join(runner.Labels, ", "),
+		)
+	}
+
+	names := make([]string, len(history.Runners))
+	for i, runner := range history.Runners {
+		names[i] = runner.Name
+	}
+	// Runner groups can be large, so keep the list on a single line
+	line := fmt.Sprintf("Runners (%d): %s", len(names), strings.Join(names, ", "))
+	if width <= 0 {
+		width = defaultTerminalWidth
+	}
+	return runewidth.Truncate(line, width, "…") + "\n"
+}
 
-		conclusion := job.Conclusion
-		if conclusion == "" {
-			conclusion = "-"
+// countRunners counts the distinct runner names among the jobs
+func countRunners(jobs []*entity.Job) int {
+	names := make(map[string]struct{})
+	for _, job := range jobs {
+		if job.RunnerName != nil {
+			names[*job.RunnerName] = struct{}{}
 		}
+	}
+	return len(names)
+}
+
+// describeFailures summarizes the workflow runs missing from a partial result
+func describeFailures(failures []repository.RunFailure) string {
+	if len(failures) == 1 {
+		return "jobs for 1 workflow run could not be fetched"
+	}
+	return fmt.Sprintf("jobs for %d workflow runs could not be fetched", len(failures))
+}
+
+// writeFailureSummary lists each workflow run missing from a partial result
+func writeFailureSummary(w io.Writer, failures []repository.RunFailure) {
+	fmt.Fprintf(w, "warning: %s:\n", describeFailures(failures))
+	for _, failure := range failures {
+		fmt.Fprintf(w, "  %s run %d: %v\n", failure.Repository, failure.RunID, failure.Err)
+	}
+}
 
-		rows[i] = table.Row{
-			job.WorkflowName,
-			job.Name,
-			fmt.Sprintf("%d", job.RunAttempt),
-			job.Status,
-			conclusion,
-			startedAt,
-			duration,
+// buildRows converts jobs to table rows
+func buildRows(jobs []*entity.Job, showRunner bool) []table.Row {
+	columns, _ := selectColumns(tableColumnKeys(showRunner))
+	rows := make([]table.Row, len(jobs))
+	for i, job := range jobs {
+		row := make(table.Row, len(columns))
+		for c, col := range columns {
+			row[c] = col.value(job)
 		}
+		rows[i] = row
 	}
 	return rows
 }
@@ -0,0 +1,139 @@
+package presentation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// changedMarker flags jobs that started or finished since the previous refresh
+const changedMarker = "●"
+
+// clockInterval is how often the durations of running jobs are updated in watch mode
+const clockInterval = time.Second
+
+// changedStyle highlights the summary of jobs that changed since the previous refresh
+var changedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+
+// refreshTickMsg is sent when it is time to re-fetch the history in watch mode
+type refreshTickMsg struct{}
+
+// clockTickMsg is sent every clockInterval in watch mode to update the durations of running jobs
+type clockTickMsg struct{}
+
+// scheduleRefresh waits for the watch interval before asking for the next refresh
+func (m *Model) scheduleRefresh() tea.Cmd {
+	if m.watch <= 0 {
+		return nil
+	}
+	return tea.Tick(m.watch, func(time.Time) tea.Msg { return refreshTickMsg{} })
+}
+
+// scheduleClock waits for the clock interval before updating the durations of running jobs
+func (m *Model) scheduleClock() tea.Cmd {
+	if m.watch <= 0 {
+		return nil
+	}
+	return tea.Tick(clockInterval, func(time.Time) tea.Msg { return clockTickMsg{} })
+}
+
+// updateRunningDurations rebuilds the table rows when jobs are running, so that their durations
+// count up between refreshes. The detail pane measures running steps when it is rendered.
+func (m *Model) updateRunningDurations() {
+	for _, job := range m.history.Jobs {
+		if job.StartedAt != nil && !job.IsCompleted() {
+			m.table.SetRows(m.tableRows())
+			return
+		}
+	}
+}
+
+// applyRefresh replaces the history with a re-fetched one, marking the jobs that changed and
+// keeping the cursor on the same job when it is still listed
+func (m *Model) applyRefresh(history *usecase.RunnerJobHistory) {
+	selected := m.selectedJob()
+	cursor := m.table.Cursor()
+	zoom, offset := m.timeline.zoom, m.timeline.offset
+
+	m.changed = changedJobs(m.history.Jobs, history.Jobs)
+	m.history = history
+	m.lastRefresh = time.Now()
+	m.buildTable()
+
+	// Keep the part of the timeline being looked at
+	m.timeline.zoom = zoom
+	m.timeline.offset = offset
+	m.timeline.clampOffset()
+
+	if selected != nil {
+		for i, job := range history.Jobs {
+			if job.ID == selected.ID {
+				cursor = i
+				break
+			}
+		}
+	}
+	m.table.SetCursor(cursor)
+}
+
+// changedJobs returns the IDs of the current jobs that are new, have started or have finished
+// since the previous history
+func changedJobs(previous, current []*entity.Job) map[int64]bool {
+	before := make(map[int64]*entity.Job, len(previous))
+	for _, job := range previous {
+		before[job.ID] = job
+	}
+
+	changed := make(map[int64]bool)
+	for _, job := range current {
+		old, ok := before[job.ID]
+		switch {
+		case !ok:
+			changed[job.ID] = true
+		case old.StartedAt == nil && job.StartedAt != nil:
+			changed[job.ID] = true
+		case !old.IsCompleted() && job.IsCompleted():
+			changed[job.ID] = true
+		}
+	}
+	return changed
+}
+
+// markChanged prefixes the first cell of the rows of changed jobs with the changed marker
+// Rows must be built from jobs.
+func markChanged(rows []table.Row, jobs []*entity.Job, changed map[int64]bool) {
+	for i, job := range jobs {
+		if changed[job.ID] {
+			rows[i][0] = changedMarker + " " + rows[i][0]
+		}
+	}
+}
+
+// renderWatchStatus describes the refresh state in watch mode, and the jobs that changed
+func (m *Model) renderWatchStatus() string {
+	if m.watch <= 0 {
+		return ""
+	}
+
+	status := fmt.Sprintf("Watching every %s, last refreshed at %s", m.watch, m.lastRefresh.Local().Format("15:04:05"))
+	if m.refreshing {
+		status += " (refreshing…)"
+	}
+	status += "\n"
+	if m.refreshErr != nil {
+		status += warningStyle.Render("⚠ Refresh failed: "+m.refreshErr.Error()) + "\n"
+	}
+	if count := len(m.changed); count > 0 {
+		jobs := "jobs"
+		if count == 1 {
+			jobs = "job"
+		}
+		status += changedStyle.Render(fmt.Sprintf("%s %d %s started or finished since the previous refresh", changedMarker, count, jobs)) + "\n"
+	}
+	return status
+}
@@ -0,0 +1,162 @@
+package presentation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/usecase"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestChangedJobs(t *testing.T) {
+	started := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	previous := []*entity.Job{
+		{ID: 1, Status: entity.StatusQueued},
+		{ID: 2, Status: entity.StatusInProgress, StartedAt: &started},
+		{ID: 3, Status: entity.StatusInProgress, StartedAt: &started},
+		{ID: 4, Status: entity.StatusCompleted, StartedAt: &started},
+	}
+	current := []*entity.Job{
+		// New job
+		{ID: 5, Status: entity.StatusQueued},
+		// Started
+		{ID: 1, Status: entity.StatusInProgress, StartedAt: &started},
+		// Finished
+		{ID: 2, Status: entity.StatusCompleted, StartedAt: &started},
+		// Unchanged
+		{ID: 3, Status: entity.StatusInProgress, StartedAt: &started},
+		{ID: 4, Status: entity.StatusCompleted, StartedAt: &started},
+	}
+
+	changed := changedJobs(previous, current)
+
+	if len(changed) != 3 || !changed[5] || !changed[1] || !changed[2] {
+		t.Errorf("changedJobs() = %v, want jobs 5, 1 and 2", changed)
+	}
+}
+
+func TestMarkChanged(t *testing.T) {
+	jobs := []*entity.Job{{ID: 1, Status: entity.StatusQueued}, {ID: 2, Status: entity.StatusQueued}}
+	rows := []table.Row{{"CI", "build"}, {"CI", "build"}}
+
+	markChanged(rows, jobs, map[int64]bool{2: true})
+
+	if rows[0][0] != "CI" || rows[1][0] != "● CI" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+}
+
+func TestModel_RefreshKeepsCursorOnSelectedJob(t *testing.T) {
+	started := time.Now().Add(-time.Hour)
+	completed := started.Add(30 * time.Second)
+	history := &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}},
+		Jobs: []*entity.Job{
+			{ID: 3, Name: "build", Status: entity.StatusInProgress, StartedAt: &started},
+			{ID: 2, Name: "build", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completed},
+			{ID: 1, Name: "build", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completed},
+		},
+	}
+	m := NewModel(history)
+	m.watch = time.Minute
+	m.table.SetCursor(1)
+
+	// A new job pushes the selected job down, and job 3 finished
+	refreshed := &usecase.RunnerJobHistory{
+		Runners: history.Runners,
+		Jobs: []*entity.Job{
+			{ID: 4, Name: "build", Status: entity.StatusInProgress, StartedAt: &started},
+			{ID: 3, Name: "build", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completed},
+			{ID: 2, Name: "build", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completed},
+			{ID: 1, Name: "build", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completed},
+		},
+	}
+	_, cmd := m.Update(historyLoadedMsg{history: refreshed})
+
+	if cmd == nil {
+		t.Error("expected the next refresh to be scheduled")
+	}
+	if job := m.selectedJob(); job == nil || job.ID != 2 {
+		t.Errorf("expected job 2 to stay selected, got %+v", job)
+	}
+	if len(m.changed) != 2 || !m.changed[4] || !m.changed[3] {
+		t.Errorf("unexpected changed jobs: %v", m.changed)
+	}
+	if status := m.renderWatchStatus(); !strings.Contains(status, "● 2 jobs started or finished since the previous refresh") {
+		t.Errorf("unexpected watch status: %q", status)
+	}
+}
+
+func TestModel_RefreshErrorKeepsHistory(t *testing.T) {
+	history := &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}},
+		Jobs:    []*entity.Job{{ID: 1, Name: "build", Status: entity.StatusQueued}},
+	}
+	m := NewModel(history)
+	m.watch = time.Minute
+	m.refreshing = true
+
+	_, cmd := m.Update(historyLoadedMsg{err: errors.New("rate limited")})
+
+	if m.err != nil || m.history != history || m.refreshing {
+		t.Errorf("expected the previous history to be kept, got err %v", m.err)
+	}
+	if cmd == nil {
+		t.Error("expected the next refresh to be scheduled")
+	}
+	if status := m.renderWatchStatus(); !strings.Contains(status, "Refresh failed: rate limited") {
+		t.Errorf("expected the refresh error in the status, got %q", status)
+	}
+}
+
+func TestModel_RefreshErrorResizesTable(t *testing.T) {
+	history := &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}},
+		Jobs:    []*entity.Job{{ID: 1, Name: "build", Status: entity.StatusQueued}},
+	}
+	m := NewModel(history)
+	m.watch = time.Minute
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	height := m.tableHeight()
+
+	m.Update(refreshTickMsg{})
+	m.Update(historyLoadedMsg{err: errors.New("rate limited")})
+
+	// The refresh error takes a line of the screen
+	if m.tableHeight() != height-1 {
+		t.Fatalf("expected the table to lose a line, got %d (was %d)", m.tableHeight(), height)
+	}
+	expected := m.table
+	expected.SetHeight(m.tableHeight())
+	if m.table.Height() != expected.Height() {
+		t.Errorf("expected the table height to follow the banners, got %d want %d", m.table.Height(), expected.Height())
+	}
+}
+
+func TestModel_ClockTickUpdatesRunningDurations(t *testing.T) {
+	started := time.Now().Add(-time.Minute)
+	job := &entity.Job{ID: 1, Name: "build", Status: entity.StatusInProgress, StartedAt: &started}
+	history := &usecase.RunnerJobHistory{
+		Runners: []*entity.Runner{{ID: 1, Name: "runner-a"}},
+		Jobs:    []*entity.Job{job},
+	}
+	m := NewModel(history)
+	m.watch = time.Minute
+
+	// The job started earlier than the table was built
+	earlier := started.Add(-time.Hour)
+	job.StartedAt = &earlier
+	_, cmd := m.Update(clockTickMsg{})
+
+	if cmd == nil {
+		t.Error("expected the next clock tick to be scheduled")
+	}
+	row := m.table.SelectedRow()
+	if duration := row[len(row)-1]; !strings.HasPrefix(duration, "1h 1m") {
+		t.Errorf("expected the running duration to be updated, got %q", duration)
+	}
+}
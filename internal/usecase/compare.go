@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
+)
+
+// JobSample summarizes the runs of a job on one runner
+type JobSample struct {
+	// Runs is the number of concluded runs, excluding skipped ones
+	Runs int
+	// Failed is the number of runs that failed or timed out
+	Failed int
+	// Durations are the execution times of the successful runs
+	Durations []time.Duration
+}
+
+// FailureRate returns the fraction of runs that failed
+// The second return value is false when the job has no concluded runs.
+func (s JobSample) FailureRate() (float64, bool) {
+	if s.Runs == 0 {
+		return 0, false
+	}
+	return float64(s.Failed) / float64(s.Runs), true
+}
+
+// MedianDuration returns the median execution time of the successful runs
+// The second return value is false when no runs succeeded.
+func (s JobSample) MedianDuration() (time.Duration, bool) {
+	if len(s.Durations) == 0 {
+		return 0, false
+	}
+	return ComputeDurationPercentiles(s.Durations).P50, true
+}
+
+// JobComparison compares the runs of a job, as keyed by newJobKey, on two runners
+type JobComparison struct {
+	Repository   string
+	WorkflowName string
+	Name         string
+	A            JobSample
+	B            JobSample
+	// DurationP is the p-value of the Mann-Whitney U test on the durations of successful runs;
+	// HasDurationP is false when either runner has fewer than two successful runs
+	DurationP    float64
+	HasDurationP bool
+	// FailureP is the p-value of Fisher's exact test on the failure counts;
+	// HasFailureP is false when either runner has no concluded runs
+	FailureP    float64
+	HasFailureP bool
+	// DurationAdjustedP and FailureAdjustedP are DurationP and FailureP adjusted with HolmAdjust
+	// for every test of the comparison
+	DurationAdjustedP float64
+	FailureAdjustedP  float64
+}
+
+// key returns the key of the compared job
+func (c *JobComparison) key() jobKey {
+	return jobKey{repository: c.Repository, workflow: c.WorkflowName, name: c.Name}
+}
+
+// IsDurationSignificant reports whether the durations differ at the significance level alpha,
+// after adjusting for every test of the comparison
+func (c *JobComparison) IsDurationSignificant(alpha float64) bool {
+	return c.HasDurationP && c.DurationAdjustedP < alpha
+}
+
+// IsFailureSignificant reports whether the failure rates differ at the significance level alpha,
+// after adjusting for every test of the comparison
+func (c *JobComparison) IsFailureSignificant(alpha float64) bool {
+	return c.HasFailureP && c.FailureAdjustedP < alpha
+}
+
+// RunnerComparison compares the jobs two runners ran in the same time window
+type RunnerComparison struct {
+	A    *entity.Runner
+	B    *entity.Runner
+	From time.Time
+	To   time.Time
+	// Jobs lists the jobs both runners ran, with the most runs first
+	Jobs []*JobComparison
+	// OnlyA and OnlyB count the jobs that only one of the runners ran
+	OnlyA int
+	OnlyB int
+	// Tests is the number of significance tests run over Jobs, which the p-values are adjusted for
+	Tests int
+	// Failures lists workflow runs whose jobs could not be fetched
+	Failures []repository.RunFailure
+}
+
+// IsPartial reports whether some workflow runs are missing from the comparison
+func (c *RunnerComparison) IsPartial() bool {
+	return len(c.Failures) > 0
+}
+
+// FetchRunnerComparison fetches every job two runners picked up since the given time and
+// compares the durations and failure rates of the jobs they both ran
+func (r *RunnerLogger) FetchRunnerComparison(ctx context.Context, nameA, nameB string, opts HistoryOptions, since time.Time) (*RunnerComparison, error) {
+	if since.IsZero() {
+		return nil, fmt.Errorf("the start of the time window is required")
+	}
+
+	runnerA, err := r.runnerRepo.FetchRunnerByName(ctx, nameA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch runner: %w", err)
+	}
+	runnerB, err := r.runnerRepo.FetchRunnerByName(ctx, nameB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch runner: %w", err)
+	}
+	if runnerA.ID == runnerB.ID {
+		return nil, fmt.Errorf("cannot compare runner '%s' with itself", runnerA.Name)
+	}
+
+	result, err := r.jobRepo.FetchJobHistory(ctx, repository.JobQuery{
+		RunnerIDs: []int64{runnerA.ID, runnerB.ID},
+		Strict:    opts.Strict,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch job history: %w", err)
+	}
+
+	var jobsA, jobsB []*entity.Job
+	for _, job := range result.Jobs {
+		if job.IsAssignedToRunner(runnerA.ID) {
+			jobsA = append(jobsA, job)
+		} else if job.IsAssignedToRunner(runnerB.ID) {
+			jobsB = append(jobsB, job)
+		}
+	}
+
+	comparison := CompareJobs(jobsA, jobsB)
+	comparison.A = runnerA
+	comparison.B = runnerB
+	comparison.From = since
+	comparison.To = time.Now()
+	comparison.Failures = result.Failures
+	return comparison, nil
+}
+
+// CompareJobs groups the concluded jobs of two runners by job and tests whether the durations
+// of successful runs and the failure rates differ
+func CompareJobs(jobsA, jobsB []*entity.Job) *RunnerComparison {
+	samplesA := sampleJobs(jobsA)
+	samplesB := sampleJobs(jobsB)
+
+	comparison := &RunnerComparison{}
+	for key, a := range samplesA {
+		b, ok := samplesB[key]
+		if !ok {
+			comparison.OnlyA++
+			continue
+		}
+
+		c := &JobComparison{Repository: key.repository, WorkflowName: key.workflow, Name: key.name, A: *a, B: *b}
+		c.DurationP, c.HasDurationP = MannWhitneyU(durationSeconds(a.Durations), durationSeconds(b.Durations))
+		c.FailureP, c.HasFailureP = FisherExact(a.Failed, a.Runs, b.Failed, b.Runs)
+		comparison.Jobs = append(comparison.Jobs, c)
+	}
+	for key := range samplesB {
+		if _, ok := samplesA[key]; !ok {
+			comparison.OnlyB++
+		}
+	}
+
+	sort.Slice(comparison.Jobs, func(i, j int) bool {
+		a, b := comparison.Jobs[i], comparison.Jobs[j]
+		if runsA, runsB := a.A.Runs+a.B.Runs, b.A.Runs+b.B.Runs; runsA != runsB {
+			return runsA > runsB
+		}
+		return a.key().less(b.key())
+	})
+	comparison.adjustPValues()
+	return comparison
+}
+
+// adjustPValues adjusts the p-values of the duration and failure tests of every job for all of
+// them being run at once
+func (c *RunnerComparison) adjustPValues() {
+	var pValues []float64
+	var targets []*float64
+	for _, job := range c.Jobs {
+		if job.HasDurationP {
+			pValues = append(pValues, job.DurationP)
+			targets = append(targets, &job.DurationAdjustedP)
+		}
+		if job.HasFailureP {
+			pValues = append(pValues, job.FailureP)
+			targets = append(targets, &job.FailureAdjustedP)
+		}
+	}
+
+	for i, p := range HolmAdjust(pValues) {
+		*targets[i] = p
+	}
+	c.Tests = len(pValues)
+}
+
+// sampleJobs groups the concluded, non-skipped jobs by job
+func sampleJobs(jobs []*entity.Job) map[jobKey]*JobSample {
+	samples := make(map[jobKey]*JobSample)
+	for _, job := range jobs {
+		if !job.IsConcluded() {
+			continue
+		}
+
+		key := newJobKey(job)
+		sample, ok := samples[key]
+		if !ok {
+			sample = &JobSample{}
+			samples[key] = sample
+		}
+		sample.Runs++
+		if job.IsFailed() {
+			sample.Failed++
+		}
+		if job.IsSucceeded() && job.StartedAt != nil && job.CompletedAt != nil {
+			sample.Durations = append(sample.Durations, job.GetExecutionDuration())
+		}
+	}
+	return samples
+}
+
+// durationSeconds converts durations to seconds for the significance tests
+func durationSeconds(durations []time.Duration) []float64 {
+	seconds := make([]float64, len(durations))
+	for i, d := range durations {
+		seconds[i] = d.Seconds()
+	}
+	return seconds
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	testhelpers "github.com/VeyronSakai/gh-runner-log/test"
+)
+
+func TestCompareJobs(t *testing.T) {
+	started := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
+	var jobsA, jobsB []*entity.Job
+	for i := 0; i < 8; i++ {
+		completedA, completedB := started.Add(time.Duration(10+i)*time.Minute), started.Add(time.Duration(5+i%2)*time.Minute)
+		jobsA = append(jobsA, &entity.Job{WorkflowName: "CI", Name: "build", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completedA})
+		jobsB = append(jobsB, &entity.Job{WorkflowName: "CI", Name: "build", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completedB})
+	}
+	jobsA = append(jobsA,
+		&entity.Job{WorkflowName: "CI", Name: "build", Status: entity.StatusCompleted, Conclusion: "failure", StartedAt: &started, CompletedAt: &started},
+		&entity.Job{WorkflowName: "CI", Name: "build", Status: entity.StatusCompleted, Conclusion: "skipped", StartedAt: &started, CompletedAt: &started},
+		&entity.Job{WorkflowName: "CI", Name: "lint", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &started},
+	)
+	jobsB = append(jobsB,
+		&entity.Job{WorkflowName: "CI", Name: "test", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &started},
+		&entity.Job{WorkflowName: "CI", Name: "deploy", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &started},
+	)
+
+	comparison := CompareJobs(jobsA, jobsB)
+
+	if len(comparison.Jobs) != 1 || comparison.OnlyA != 1 || comparison.OnlyB != 2 {
+		t.Fatalf("unexpected comparison: %d jobs, %d only on A, %d only on B", len(comparison.Jobs), comparison.OnlyA, comparison.OnlyB)
+	}
+
+	build := comparison.Jobs[0]
+	if build.A.Runs != 9 || build.A.Failed != 1 || len(build.A.Durations) != 8 {
+		t.Errorf("unexpected sample for runner A: %+v", build.A)
+	}
+	if median, ok := build.B.MedianDuration(); !ok || median != 5*time.Minute {
+		t.Errorf("unexpected median for runner B: %v", median)
+	}
+	if !build.IsDurationSignificant(0.05) {
+		t.Errorf("expected a significant duration difference, p = %v", build.DurationP)
+	}
+	if build.IsFailureSignificant(0.05) || !build.HasFailureP {
+		t.Errorf("expected no significant failure difference, p = %v", build.FailureP)
+	}
+	if comparison.Tests != 2 || build.DurationAdjustedP != 2*build.DurationP {
+		t.Errorf("expected the duration p-value adjusted for 2 tests, got %d tests and %v", comparison.Tests, build.DurationAdjustedP)
+	}
+}
+
+func TestCompareJobs_AdjustsForMultipleTests(t *testing.T) {
+	started := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
+	var jobsA, jobsB []*entity.Job
+	// On its own, p is about 0.036
+	for i := 1; i <= 6; i++ {
+		completedA, completedB := started.Add(time.Duration(i)*time.Minute), started.Add(time.Duration(i+3)*time.Minute)
+		jobsA = append(jobsA, &entity.Job{WorkflowName: "CI", Name: "build", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completedA})
+		jobsB = append(jobsB, &entity.Job{WorkflowName: "CI", Name: "build", Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completedB})
+	}
+	completed := started.Add(time.Minute)
+	for _, name := range []string{"lint", "test", "e2e", "deploy"} {
+		for i := 0; i < 2; i++ {
+			jobsA = append(jobsA, &entity.Job{WorkflowName: "CI", Name: name, Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completed})
+			jobsB = append(jobsB, &entity.Job{WorkflowName: "CI", Name: name, Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &started, CompletedAt: &completed})
+		}
+	}
+
+	comparison := CompareJobs(jobsA, jobsB)
+
+	if comparison.Tests != 10 {
+		t.Errorf("expected a duration and a failure test for each of the 5 jobs, got %d tests", comparison.Tests)
+	}
+	build := comparison.Jobs[0]
+	if build.Name != "build" || build.DurationP >= 0.05 {
+		t.Fatalf("expected build to differ before adjusting, got %+v", build)
+	}
+	if build.IsDurationSignificant(0.05) {
+		t.Errorf("expected no significant difference after adjusting for 10 tests, adjusted p = %v", build.DurationAdjustedP)
+	}
+}
+
+func TestFetchRunnerComparison(t *testing.T) {
+	runnerRepo := &testhelpers.StubRunnerRepository{Runners: []*entity.Runner{
+		{ID: 1, Name: "old"},
+		{ID: 2, Name: "new"},
+	}}
+	started := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
+	completed := started.Add(5 * time.Minute)
+	jobRepo := &testhelpers.StubJobRepository{Jobs: []*entity.Job{
+		{Name: "build", Status: entity.StatusCompleted, Conclusion: "success", RunnerID: ptrInt64(1), StartedAt: &started, CompletedAt: &completed},
+		{Name: "build", Status: entity.StatusCompleted, Conclusion: "success", RunnerID: ptrInt64(2), StartedAt: &started, CompletedAt: &completed},
+		{Name: "build", Status: entity.StatusCompleted, Conclusion: "success", RunnerID: ptrInt64(3), StartedAt: &started, CompletedAt: &completed},
+	}}
+	runnerLogger := NewRunnerLogger(jobRepo, runnerRepo)
+	since := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+
+	comparison, err := runnerLogger.FetchRunnerComparison(context.Background(), "old", "new", HistoryOptions{}, since)
+	if err != nil {
+		t.Fatalf("FetchRunnerComparison error: %v", err)
+	}
+	if comparison.A.Name != "old" || comparison.B.Name != "new" || len(comparison.Jobs) != 1 {
+		t.Fatalf("unexpected comparison: %+v", comparison)
+	}
+	if job := comparison.Jobs[0]; job.A.Runs != 1 || job.B.Runs != 1 || job.HasDurationP {
+		t.Errorf("unexpected job comparison: %+v", job)
+	}
+
+	if _, err := runnerLogger.FetchRunnerComparison(context.Background(), "old", "old", HistoryOptions{}, since); err == nil {
+		t.Error("expected an error when comparing a runner with itself")
+	}
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
+)
+
+// FlakyAttempt is a failed attempt of a job that succeeded when its workflow run was retried
+type FlakyAttempt struct {
+	Failed *entity.Job
+	// Succeeded is the first later attempt of the same job that succeeded
+	Succeeded *entity.Job
+}
+
+// FlakyJob groups the flaky attempts of a job across workflow runs
+// Jobs are identified by repository, workflow name and job name.
+type FlakyJob struct {
+	Repository   string
+	WorkflowName string
+	Name         string
+	// Attempts are sorted by the start time of the failed attempt, most recent first
+	Attempts []FlakyAttempt
+	// FailedOnSelected is the number of failed attempts that ran on the selected runners
+	FailedOnSelected int
+}
+
+// key returns the key of the job the attempts belong to
+func (f *FlakyJob) key() jobKey {
+	return jobKey{repository: f.Repository, workflow: f.WorkflowName, name: f.Name}
+}
+
+// RunnerFlakiness counts the jobs a runner ran and how many of them were flaky failures
+type RunnerFlakiness struct {
+	Runner   string
+	Selected bool
+	// Jobs is the number of concluded jobs the runner ran, excluding skipped ones
+	Jobs int
+	// FlakyFailures is the number of those jobs that failed and later succeeded on a retry
+	FlakyFailures int
+}
+
+// FlakyRate returns the fraction of the runner's jobs that were flaky failures
+// The second return value is false when the runner ran no jobs.
+func (f RunnerFlakiness) FlakyRate() (float64, bool) {
+	if f.Jobs == 0 {
+		return 0, false
+	}
+	return float64(f.FlakyFailures) / float64(f.Jobs), true
+}
+
+// FlakyReport describes flaky jobs and whether their failures concentrate on the selected runners
+type FlakyReport struct {
+	// History holds the selected runners and their jobs in the window
+	History *RunnerJobHistory
+	From    time.Time
+	To      time.Time
+	// FlakyJobs are the jobs with a flaky attempt involving the selected runners, sorted by
+	// number of flaky attempts, most first
+	FlakyJobs []*FlakyJob
+	// Runners lists the selected runners and every runner with flaky failures, sorted by
+	// number of flaky failures, most first
+	Runners []RunnerFlakiness
+	// Selected and Others total the selected runners and every other runner in scope
+	Selected RunnerFlakiness
+	Others   RunnerFlakiness
+}
+
+// FetchFlakyJobs fetches every job in scope since the given time and reports jobs that failed
+// on one attempt of their workflow run and succeeded on a later one
+// Retries are often picked up by other runners, so the jobs of every runner are fetched and
+// compared with the jobs of the selected runners.
+func (r *RunnerLogger) FetchFlakyJobs(ctx context.Context, selector RunnerSelector, opts HistoryOptions, since time.Time) (*FlakyReport, error) {
+	if since.IsZero() {
+		return nil, fmt.Errorf("the start of the time window is required")
+	}
+
+	history, selection, err := r.selectRunners(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.jobRepo.FetchJobHistory(ctx, repository.JobQuery{Strict: opts.Strict})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch job history: %w", err)
+	}
+
+	jobs := result.Jobs
+	entity.SortByStartedAtDesc(jobs)
+	for _, job := range jobs {
+		if selection.Matches(job) {
+			history.Jobs = append(history.Jobs, job)
+		}
+	}
+	history.Failures = result.Failures
+
+	report := ComputeFlakyReport(jobs, selection.Matches)
+	report.History = history
+	report.From = since
+	report.To = time.Now()
+	return report, nil
+}
+
+// flakyKey identifies the attempts of a job within a workflow run
+type flakyKey struct {
+	repository string
+	runID      int64
+	name       string
+}
+
+// ComputeFlakyReport finds failed attempts of jobs that succeeded on a later attempt of the same
+// workflow run, and counts them per runner; selected reports whether a job ran on a selected runner
+func ComputeFlakyReport(jobs []*entity.Job, selected func(*entity.Job) bool) *FlakyReport {
+	report := &FlakyReport{}
+
+	attempts := make(map[flakyKey][]*entity.Job)
+	runners := make(map[string]*RunnerFlakiness)
+	for _, job := range jobs {
+		key := flakyKey{repository: job.Repository, runID: job.RunID, name: job.Name}
+		attempts[key] = append(attempts[key], job)
+
+		isSelected := selected(job)
+		if job.RunnerName != nil && *job.RunnerName != "" {
+			if _, ok := runners[*job.RunnerName]; !ok {
+				runners[*job.RunnerName] = &RunnerFlakiness{Runner: *job.RunnerName}
+			}
+			runners[*job.RunnerName].Selected = runners[*job.RunnerName].Selected || isSelected
+		}
+		if !job.IsConcluded() {
+			continue
+		}
+		countFlakiness(report, runners, job, isSelected, func(f *RunnerFlakiness) { f.Jobs++ })
+	}
+
+	flakyJobs := make(map[jobKey]*FlakyJob)
+	for _, runAttempts := range attempts {
+		sort.SliceStable(runAttempts, func(i, j int) bool { return runAttempts[i].RunAttempt < runAttempts[j].RunAttempt })
+
+		for i, failed := range runAttempts {
+			if !failed.IsFailed() {
+				continue
+			}
+			var succeeded *entity.Job
+			for _, later := range runAttempts[i+1:] {
+				if later.RunAttempt > failed.RunAttempt && later.IsSucceeded() {
+					succeeded = later
+					break
+				}
+			}
+			if succeeded == nil {
+				continue
+			}
+
+			failedOnSelected := selected(failed)
+			countFlakiness(report, runners, failed, failedOnSelected, func(f *RunnerFlakiness) { f.FlakyFailures++ })
+			if !failedOnSelected && !selected(succeeded) {
+				continue
+			}
+
+			key := newJobKey(failed)
+			flakyJob, ok := flakyJobs[key]
+			if !ok {
+				flakyJob = &FlakyJob{Repository: failed.Repository, WorkflowName: failed.WorkflowName, Name: failed.Name}
+				flakyJobs[key] = flakyJob
+				report.FlakyJobs = append(report.FlakyJobs, flakyJob)
+			}
+			flakyJob.Attempts = append(flakyJob.Attempts, FlakyAttempt{Failed: failed, Succeeded: succeeded})
+			if failedOnSelected {
+				flakyJob.FailedOnSelected++
+			}
+		}
+	}
+
+	for _, flakyJob := range report.FlakyJobs {
+		sort.SliceStable(flakyJob.Attempts, func(i, j int) bool {
+			return startedAfter(flakyJob.Attempts[i].Failed, flakyJob.Attempts[j].Failed)
+		})
+	}
+	sort.SliceStable(report.FlakyJobs, func(i, j int) bool {
+		a, b := report.FlakyJobs[i], report.FlakyJobs[j]
+		if len(a.Attempts) != len(b.Attempts) {
+			return len(a.Attempts) > len(b.Attempts)
+		}
+		return a.key().less(b.key())
+	})
+
+	for _, flakiness := range runners {
+		if flakiness.Selected || flakiness.FlakyFailures > 0 {
+			report.Runners = append(report.Runners, *flakiness)
+		}
+	}
+	sort.Slice(report.Runners, func(i, j int) bool {
+		a, b := report.Runners[i], report.Runners[j]
+		if a.FlakyFailures != b.FlakyFailures {
+			return a.FlakyFailures > b.FlakyFailures
+		}
+		return a.Runner < b.Runner
+	})
+
+	return report
+}
+
+// countFlakiness applies a count to the job's runner and to the selected or other runners' totals
+func countFlakiness(report *FlakyReport, runners map[string]*RunnerFlakiness, job *entity.Job, isSelected bool, count func(*RunnerFlakiness)) {
+	if isSelected {
+		count(&report.Selected)
+	} else {
+		count(&report.Others)
+	}
+	if job.RunnerName != nil {
+		if flakiness, ok := runners[*job.RunnerName]; ok {
+			count(flakiness)
+		}
+	}
+}
+
+// startedAfter reports whether job a started after job b; jobs that have not started come last
+func startedAfter(a, b *entity.Job) bool {
+	if a.StartedAt == nil || b.StartedAt == nil {
+		return a.StartedAt != nil
+	}
+	return a.StartedAt.After(*b.StartedAt)
+}
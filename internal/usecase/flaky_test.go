@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	testhelpers "github.com/VeyronSakai/gh-runner-log/test"
+)
+
+func TestComputeFlakyReport(t *testing.T) {
+	attempts := []struct {
+		run        int64
+		attempt    int
+		name       string
+		conclusion string
+		runner     string
+	}{
+		// Flaky: failed on runner-a, succeeded on runner-b
+		{1, 1, "test", "failure", "runner-a"},
+		{1, 2, "test", "success", "runner-b"},
+		// Flaky twice in the same run: timed out, failed, then succeeded
+		{2, 1, "test", "timed_out", "runner-a"},
+		{2, 2, "test", "failure", "runner-b"},
+		{2, 3, "test", "success", "runner-a"},
+		// Not flaky: never succeeded
+		{3, 1, "lint", "failure", "runner-a"},
+		{3, 2, "lint", "failure", "runner-a"},
+		// Not flaky: a different job of the same run succeeded
+		{4, 1, "build", "failure", "runner-b"},
+		{4, 2, "deploy", "success", "runner-b"},
+		// Flaky without the selected runner: counted for runner-c but not listed
+		{5, 1, "e2e", "failure", "runner-c"},
+		{5, 2, "e2e", "success", "runner-c"},
+		{6, 1, "skip", "skipped", "runner-a"},
+	}
+	jobs := make([]*entity.Job, len(attempts))
+	for i, a := range attempts {
+		started := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC).Add(time.Duration(a.run)*time.Hour + time.Duration(a.attempt)*time.Minute)
+		jobs[i] = &entity.Job{
+			ID:           a.run*100 + int64(a.attempt),
+			RunID:        a.run,
+			RunAttempt:   a.attempt,
+			Name:         a.name,
+			Status:       entity.StatusCompleted,
+			Conclusion:   a.conclusion,
+			RunnerName:   ptrString(a.runner),
+			StartedAt:    &started,
+			WorkflowName: "CI",
+			Repository:   "owner/repo",
+		}
+	}
+	selected := func(job *entity.Job) bool { return *job.RunnerName == "runner-a" }
+
+	report := ComputeFlakyReport(jobs, selected)
+
+	if len(report.FlakyJobs) != 1 {
+		t.Fatalf("expected 1 flaky job, got %d", len(report.FlakyJobs))
+	}
+	flaky := report.FlakyJobs[0]
+	if flaky.Name != "test" || len(flaky.Attempts) != 3 || flaky.FailedOnSelected != 2 {
+		t.Errorf("unexpected flaky job: %+v", flaky)
+	}
+	// The most recent failed attempt comes first, paired with the first later success
+	if first := flaky.Attempts[0]; first.Failed.RunID != 2 || first.Failed.RunAttempt != 2 || first.Succeeded.RunAttempt != 3 {
+		t.Errorf("unexpected first attempt: failed %+v, succeeded %+v", first.Failed, first.Succeeded)
+	}
+
+	if report.Selected.Jobs != 5 || report.Selected.FlakyFailures != 2 {
+		t.Errorf("unexpected selected totals: %+v", report.Selected)
+	}
+	if report.Others.Jobs != 6 || report.Others.FlakyFailures != 2 {
+		t.Errorf("unexpected other totals: %+v", report.Others)
+	}
+
+	want := []RunnerFlakiness{
+		{Runner: "runner-a", Selected: true, Jobs: 5, FlakyFailures: 2},
+		{Runner: "runner-b", Jobs: 4, FlakyFailures: 1},
+		{Runner: "runner-c", Jobs: 2, FlakyFailures: 1},
+	}
+	if len(report.Runners) != len(want) {
+		t.Fatalf("unexpected runners: %+v", report.Runners)
+	}
+	for i := range want {
+		if report.Runners[i] != want[i] {
+			t.Errorf("runners[%d] = %+v, want %+v", i, report.Runners[i], want[i])
+		}
+	}
+}
+
+func TestRunnerFlakiness_FlakyRate(t *testing.T) {
+	if _, ok := (RunnerFlakiness{}).FlakyRate(); ok {
+		t.Error("expected no rate without jobs")
+	}
+	if rate, ok := (RunnerFlakiness{Jobs: 4, FlakyFailures: 1}).FlakyRate(); !ok || rate != 0.25 {
+		t.Errorf("FlakyRate() = %v, %v", rate, ok)
+	}
+}
+
+func TestFetchFlakyJobs_FetchesEveryRunner(t *testing.T) {
+	started := time.Date(2025, 11, 16, 1, 0, 0, 0, time.UTC)
+	retried := started.Add(time.Minute)
+	failed := &entity.Job{
+		ID:           101,
+		RunID:        1,
+		RunAttempt:   1,
+		Name:         "test",
+		Status:       entity.StatusCompleted,
+		Conclusion:   "failure",
+		RunnerID:     ptrInt64(7),
+		RunnerName:   ptrString("runner-a"),
+		StartedAt:    &started,
+		WorkflowName: "CI",
+		Repository:   "owner/repo",
+	}
+	succeeded := &entity.Job{
+		ID:           102,
+		RunID:        1,
+		RunAttempt:   2,
+		Name:         "test",
+		Status:       entity.StatusCompleted,
+		Conclusion:   "success",
+		RunnerID:     ptrInt64(8),
+		RunnerName:   ptrString("runner-b"),
+		StartedAt:    &retried,
+		WorkflowName: "CI",
+		Repository:   "owner/repo",
+	}
+	jobRepo := &testhelpers.StubJobRepository{Jobs: []*entity.Job{failed, succeeded}}
+	runnerRepo := &testhelpers.StubRunnerRepository{Runner: &entity.Runner{ID: 7, Name: "runner-a"}}
+
+	runnerLogger := NewRunnerLogger(jobRepo, runnerRepo)
+	since := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	report, err := runnerLogger.FetchFlakyJobs(context.Background(), RunnerSelector{Names: []string{"runner-a"}}, HistoryOptions{}, since)
+	if err != nil {
+		t.Fatalf("FetchFlakyJobs error: %v", err)
+	}
+
+	if len(jobRepo.LastQuery.RunnerIDs) != 0 || jobRepo.LastQuery.Limit != 0 {
+		t.Errorf("expected an unfiltered query, got %+v", jobRepo.LastQuery)
+	}
+	if len(report.History.Jobs) != 1 || report.History.Jobs[0] != failed {
+		t.Errorf("expected only the selected runner's job in the history, got %v", report.History.Jobs)
+	}
+	if len(report.FlakyJobs) != 1 || report.FlakyJobs[0].Attempts[0].Succeeded != succeeded {
+		t.Errorf("expected the retry on another runner to be found, got %+v", report.FlakyJobs)
+	}
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
+)
+
+// FleetOptions controls how the fleet overview is built
+type FleetOptions struct {
+	// Labels restricts the overview to runners that have all of these labels
+	Labels []string
+	// Strict fails the whole fetch when the jobs of any workflow run cannot be retrieved
+	Strict bool
+}
+
+// RunnerSummary summarizes the jobs a runner picked up in the time window
+type RunnerSummary struct {
+	Runner *entity.Runner
+	// LastJob is the most recently started job, or nil when the runner picked up no jobs
+	LastJob *entity.Job
+	// Stats summarizes the jobs the runner picked up
+	Stats *JobStats
+}
+
+// IsIdle reports whether the runner picked up no jobs in the time window
+func (s *RunnerSummary) IsIdle() bool {
+	return s.Stats.Total == 0
+}
+
+// FleetSummary represents the activity of every runner in scope
+type FleetSummary struct {
+	// Runners are sorted by name
+	Runners []*RunnerSummary
+	// Failures lists workflow runs whose jobs could not be fetched
+	Failures []repository.RunFailure
+}
+
+// IsPartial reports whether some workflow runs are missing from the summary
+func (s *FleetSummary) IsPartial() bool {
+	return len(s.Failures) > 0
+}
+
+// FetchFleetSummary lists every runner in scope and summarizes the jobs each one picked up
+// since the given time
+func (r *RunnerLogger) FetchFleetSummary(ctx context.Context, opts FleetOptions, since time.Time) (*FleetSummary, error) {
+	runners, err := r.runnerRepo.FetchRunners(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch runners: %w", err)
+	}
+
+	summary := &FleetSummary{}
+	byID := make(map[int64]*RunnerSummary)
+	query := repository.JobQuery{Strict: opts.Strict}
+	for _, runner := range runners {
+		if !runner.HasLabels(opts.Labels...) {
+			continue
+		}
+		runnerSummary := &RunnerSummary{Runner: runner}
+		summary.Runners = append(summary.Runners, runnerSummary)
+		byID[runner.ID] = runnerSummary
+		query.RunnerIDs = append(query.RunnerIDs, runner.ID)
+	}
+
+	sort.SliceStable(summary.Runners, func(i, j int) bool {
+		return summary.Runners[i].Runner.Name < summary.Runners[j].Runner.Name
+	})
+
+	// Without runners there is nothing to summarize, and an empty RunnerIDs would match every job
+	if len(summary.Runners) == 0 {
+		return summary, nil
+	}
+
+	result, err := r.jobRepo.FetchJobHistory(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch job history: %w", err)
+	}
+
+	// Jobs are newest first, so the first job seen for a runner is its last job
+	jobs := result.Jobs
+	entity.SortByStartedAtDesc(jobs)
+	jobsByRunner := make(map[int64][]*entity.Job)
+	for _, job := range jobs {
+		if job.RunnerID == nil {
+			continue
+		}
+		runnerSummary, ok := byID[*job.RunnerID]
+		if !ok {
+			continue
+		}
+
+		if runnerSummary.LastJob == nil {
+			runnerSummary.LastJob = job
+		}
+		jobsByRunner[*job.RunnerID] = append(jobsByRunner[*job.RunnerID], job)
+	}
+
+	now := time.Now()
+	for _, runnerSummary := range summary.Runners {
+		runnerSummary.Stats = ComputeJobStats(jobsByRunner[runnerSummary.Runner.ID], since, now)
+	}
+	summary.Failures = result.Failures
+	return summary, nil
+}
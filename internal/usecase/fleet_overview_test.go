@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
+	testhelpers "github.com/VeyronSakai/gh-runner-log/test"
+)
+
+func TestFetchFleetSummary(t *testing.T) {
+	runners := []*entity.Runner{
+		{ID: 2, Name: "mac-a", Labels: []string{"self-hosted", "macos"}},
+		{ID: 1, Name: "linux-a", Labels: []string{"self-hosted", "linux"}, Busy: true},
+		{ID: 3, Name: "linux-b", Labels: []string{"self-hosted", "linux"}},
+	}
+	start := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	jobs := []*entity.Job{
+		{ID: 10, RunnerID: ptrInt64(1), Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &start},
+		{ID: 11, RunnerID: ptrInt64(1), Status: entity.StatusCompleted, Conclusion: "failure", StartedAt: ptrTime(start.Add(time.Hour))},
+		{ID: 12, RunnerID: ptrInt64(1), Status: entity.StatusCompleted, Conclusion: "skipped", StartedAt: ptrTime(start.Add(-time.Hour))},
+		{ID: 13, RunnerID: ptrInt64(1), Status: entity.StatusInProgress, StartedAt: ptrTime(start.Add(2 * time.Hour))},
+		{ID: 14, RunnerID: ptrInt64(2), Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &start},
+		{ID: 15, RunnerID: ptrInt64(99), Status: entity.StatusCompleted, Conclusion: "success", StartedAt: &start},
+	}
+
+	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{Jobs: jobs}, &testhelpers.StubRunnerRepository{Runners: runners})
+	summary, err := runnerLogger.FetchFleetSummary(context.Background(), FleetOptions{}, start.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("FetchFleetSummary error: %v", err)
+	}
+
+	if len(summary.Runners) != 3 {
+		t.Fatalf("expected 3 runners, got %d", len(summary.Runners))
+	}
+	linuxA, linuxB, macA := summary.Runners[0], summary.Runners[1], summary.Runners[2]
+	if linuxA.Runner.Name != "linux-a" || linuxB.Runner.Name != "linux-b" || macA.Runner.Name != "mac-a" {
+		t.Fatalf("expected runners sorted by name, got %s, %s, %s", linuxA.Runner.Name, linuxB.Runner.Name, macA.Runner.Name)
+	}
+
+	if linuxA.Stats.Total != 4 || linuxA.LastJob == nil || linuxA.LastJob.ID != 13 {
+		t.Errorf("unexpected linux-a summary: %+v", linuxA)
+	}
+	// Skipped and in-progress jobs do not count towards the success rate
+	if rate, ok := linuxA.Stats.SuccessRate(); !ok || rate != 0.5 {
+		t.Errorf("expected linux-a success rate 0.5, got %v (%v)", rate, ok)
+	}
+
+	if !linuxB.IsIdle() || linuxB.LastJob != nil {
+		t.Errorf("expected linux-b to be idle, got %+v", linuxB)
+	}
+	if _, ok := linuxB.Stats.SuccessRate(); ok {
+		t.Error("expected no success rate for an idle runner")
+	}
+
+	if rate, ok := macA.Stats.SuccessRate(); !ok || rate != 1 {
+		t.Errorf("expected mac-a success rate 1, got %v (%v)", rate, ok)
+	}
+}
+
+func TestFetchFleetSummary_FiltersByLabel(t *testing.T) {
+	runners := []*entity.Runner{
+		{ID: 1, Name: "linux-a", Labels: []string{"linux"}},
+		{ID: 2, Name: "mac-a", Labels: []string{"macos"}},
+	}
+	jobRepo := &testhelpers.StubJobRepository{}
+
+	runnerLogger := NewRunnerLogger(jobRepo, &testhelpers.StubRunnerRepository{Runners: runners})
+	summary, err := runnerLogger.FetchFleetSummary(context.Background(), FleetOptions{Labels: []string{"macos"}}, time.Time{})
+	if err != nil {
+		t.Fatalf("FetchFleetSummary error: %v", err)
+	}
+
+	if len(summary.Runners) != 1 || summary.Runners[0].Runner.Name != "mac-a" {
+		t.Fatalf("expected only mac-a, got %+v", summary.Runners)
+	}
+	if len(jobRepo.LastQuery.RunnerIDs) != 1 || jobRepo.LastQuery.RunnerIDs[0] != 2 {
+		t.Errorf("expected jobs to be fetched for runner 2 only, got %v", jobRepo.LastQuery.RunnerIDs)
+	}
+}
+
+func TestFetchFleetSummary_ReportsPartialFailures(t *testing.T) {
+	runners := []*entity.Runner{{ID: 1, Name: "linux-a"}}
+	jobRepo := &testhelpers.StubJobRepository{
+		Failures: []repository.RunFailure{{RunID: 5, Repository: "acme/app", Err: errors.New("boom")}},
+	}
+
+	runnerLogger := NewRunnerLogger(jobRepo, &testhelpers.StubRunnerRepository{Runners: runners})
+	summary, err := runnerLogger.FetchFleetSummary(context.Background(), FleetOptions{}, time.Time{})
+	if err != nil {
+		t.Fatalf("FetchFleetSummary error: %v", err)
+	}
+	if !summary.IsPartial() {
+		t.Error("expected partial summary")
+	}
+
+	if _, err := runnerLogger.FetchFleetSummary(context.Background(), FleetOptions{Strict: true}, time.Time{}); err == nil {
+		t.Error("expected error in strict mode")
+	}
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+)
+
+// HeatmapMetric selects what a load heatmap measures
+type HeatmapMetric string
+
+// Heatmap metrics
+const (
+	// HeatmapBusy measures the time spent running jobs
+	HeatmapBusy HeatmapMetric = "busy"
+	// HeatmapJobs counts the jobs that started
+	HeatmapJobs HeatmapMetric = "jobs"
+)
+
+// ParseHeatmapMetric validates a metric name
+func ParseHeatmapMetric(s string) (HeatmapMetric, error) {
+	switch metric := HeatmapMetric(s); metric {
+	case HeatmapBusy, HeatmapJobs:
+		return metric, nil
+	default:
+		return "", fmt.Errorf("unknown heatmap metric %q (use %q or %q)", s, HeatmapBusy, HeatmapJobs)
+	}
+}
+
+// Weekdays lists the days of the week starting on Monday, the order heatmaps are shown in
+var Weekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
+
+// LoadHeatmap buckets the load of the selected runners by day of week and hour of day
+// Buckets are indexed by time.Weekday and hour, and sum every matching hour in the window;
+// Covered tells how much of the window fell on each bucket, to average over.
+type LoadHeatmap struct {
+	From     time.Time
+	To       time.Time
+	Location *time.Location
+	// Jobs counts the jobs that started in each hour
+	Jobs [7][24]int
+	// Busy is the time spent running jobs in each hour, summed over jobs
+	Busy [7][24]time.Duration
+	// Covered is the part of the window falling on each hour, summed over weeks
+	Covered [7][24]time.Duration
+}
+
+// IsCovered reports whether any part of the window fell on the hour
+func (h *LoadHeatmap) IsCovered(day time.Weekday, hour int) bool {
+	return h.Covered[day][hour] > 0
+}
+
+// CoveredHours counts the hours of the week that the window covered at least in part
+func (h *LoadHeatmap) CoveredHours() int {
+	var covered int
+	for _, slot := range heatmapSlots() {
+		if h.IsCovered(slot.day, slot.hour) {
+			covered++
+		}
+	}
+	return covered
+}
+
+// Value returns the bucket for the metric averaged over the times the window covered the hour,
+// busy minutes or jobs started per hour; hours the window did not cover are 0
+func (h *LoadHeatmap) Value(metric HeatmapMetric, day time.Weekday, hour int) float64 {
+	if !h.IsCovered(day, hour) {
+		return 0
+	}
+	covered := h.Covered[day][hour].Hours()
+	if metric == HeatmapJobs {
+		return float64(h.Jobs[day][hour]) / covered
+	}
+	return h.Busy[day][hour].Minutes() / covered
+}
+
+// Max returns the largest bucket for the metric
+func (h *LoadHeatmap) Max(metric HeatmapMetric) float64 {
+	var highest float64
+	for day := range h.Jobs {
+		for hour := range h.Jobs[day] {
+			highest = max(highest, h.Value(metric, time.Weekday(day), hour))
+		}
+	}
+	return highest
+}
+
+// HeatmapWindow is a run of consecutive hours in the week
+type HeatmapWindow struct {
+	Weekday time.Weekday
+	Hour    int
+	Hours   int
+	// Jobs and Busy total the averaged buckets inside the window, the load of a typical week
+	Jobs float64
+	Busy time.Duration
+}
+
+// QuietestWindow finds the run of consecutive hours with the least average busy time, wrapping
+// from Sunday into Monday, breaking ties by fewer jobs and then by the earliest start in the week
+// Only runs of hours the window covered count, since nothing is known about the others; the
+// second return value is false when there are none.
+func (h *LoadHeatmap) QuietestWindow(hours int) (HeatmapWindow, bool) {
+	hours = min(max(hours, 1), 7*24)
+
+	slots := heatmapSlots()
+	var quietest HeatmapWindow
+	found := false
+	for i, start := range slots {
+		window := HeatmapWindow{Weekday: start.day, Hour: start.hour, Hours: hours}
+		covered := true
+		for offset := 0; offset < hours && covered; offset++ {
+			slot := slots[(i+offset)%len(slots)]
+			covered = h.IsCovered(slot.day, slot.hour)
+			window.Jobs += h.Value(HeatmapJobs, slot.day, slot.hour)
+			window.Busy += time.Duration(h.Value(HeatmapBusy, slot.day, slot.hour) * float64(time.Minute))
+		}
+		if !covered {
+			continue
+		}
+		if !found || window.Busy < quietest.Busy || (window.Busy == quietest.Busy && window.Jobs < quietest.Jobs) {
+			quietest = window
+			found = true
+		}
+	}
+	return quietest, found
+}
+
+// heatmapSlot identifies an hour of the week
+type heatmapSlot struct {
+	day  time.Weekday
+	hour int
+}
+
+// heatmapSlots lists every hour of the week in order, starting on Monday at midnight
+func heatmapSlots() []heatmapSlot {
+	slots := make([]heatmapSlot, 0, 7*24)
+	for _, day := range Weekdays {
+		for hour := 0; hour < 24; hour++ {
+			slots = append(slots, heatmapSlot{day: day, hour: hour})
+		}
+	}
+	return slots
+}
+
+// RunnerHeatmap is the load heatmap report for the selected runners
+type RunnerHeatmap struct {
+	History *RunnerJobHistory
+	Heatmap *LoadHeatmap
+}
+
+// FetchRunnerHeatmap fetches every job the selected runners picked up since the given time
+// and buckets their load by weekday and hour in the local time zone
+func (r *RunnerLogger) FetchRunnerHeatmap(ctx context.Context, selector RunnerSelector, opts HistoryOptions, since time.Time) (*RunnerHeatmap, error) {
+	history, err := r.fetchWindowHistory(ctx, selector, opts, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunnerHeatmap{
+		History: history,
+		Heatmap: ComputeLoadHeatmap(history.Jobs, since, time.Now(), time.Local),
+	}, nil
+}
+
+// ComputeLoadHeatmap buckets the jobs between from and to by weekday and hour in loc
+// A job counts towards the hour it started in, and its busy time is split over the hours it ran.
+// Jobs still in progress count as busy until to; jobs that have not started are ignored.
+func ComputeLoadHeatmap(jobs []*entity.Job, from, to time.Time, loc *time.Location) *LoadHeatmap {
+	heatmap := &LoadHeatmap{From: from, To: to, Location: loc}
+	if from.Before(to) {
+		addHourly(&heatmap.Covered, interval{from: from, to: to}, loc)
+	}
+
+	for _, job := range jobs {
+		if job.StartedAt == nil {
+			continue
+		}
+
+		if !job.StartedAt.Before(from) && job.StartedAt.Before(to) {
+			started := job.StartedAt.In(loc)
+			heatmap.Jobs[started.Weekday()][started.Hour()]++
+		}
+
+		end := to
+		if job.CompletedAt != nil {
+			end = *job.CompletedAt
+		}
+		if busy, ok := clip(interval{from: *job.StartedAt, to: end}, from, to); ok {
+			addHourly(&heatmap.Busy, busy, loc)
+		}
+	}
+
+	return heatmap
+}
+
+// addHourly splits the interval over the weekday and hour buckets it spans in loc
+func addHourly(buckets *[7][24]time.Duration, span interval, loc *time.Location) {
+	for t := span.from.In(loc); t.Before(span.to); {
+		next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		// Around daylight saving changes the next wall-clock hour can be ambiguous
+		if !next.After(t) {
+			next = t.Truncate(time.Hour).Add(time.Hour)
+		}
+		if next.After(span.to) {
+			next = span.to
+		}
+		buckets[t.Weekday()][t.Hour()] += next.Sub(t)
+		t = next.In(loc)
+	}
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+)
+
+func TestComputeLoadHeatmap(t *testing.T) {
+	// 2025-11-17 is a Monday
+	from := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+	jobs := []*entity.Job{
+		// Runs across three hours on Monday
+		{StartedAt: ptrTime(from.Add(9*time.Hour + 30*time.Minute)), CompletedAt: ptrTime(from.Add(11*time.Hour + 15*time.Minute))},
+		{StartedAt: ptrTime(from.Add(9*time.Hour + 45*time.Minute)), CompletedAt: ptrTime(from.Add(10 * time.Hour))},
+		// Still running at the end of the window, on Sunday
+		{StartedAt: ptrTime(to.Add(-30 * time.Minute))},
+		// Started before the window: its busy time counts, the job does not
+		{StartedAt: ptrTime(from.Add(-time.Hour)), CompletedAt: ptrTime(from.Add(30 * time.Minute))},
+		{Status: entity.StatusQueued},
+	}
+
+	heatmap := ComputeLoadHeatmap(jobs, from, to, time.UTC)
+
+	if heatmap.Jobs[time.Monday][9] != 2 || heatmap.Jobs[time.Sunday][23] != 1 || heatmap.Jobs[time.Monday][0] != 0 {
+		t.Errorf("unexpected job counts: %v", heatmap.Jobs)
+	}
+	for hour, want := range map[int]time.Duration{0: 30 * time.Minute, 9: 45 * time.Minute, 10: time.Hour, 11: 15 * time.Minute} {
+		if got := heatmap.Busy[time.Monday][hour]; got != want {
+			t.Errorf("busy time on Monday at %02d:00 = %v, want %v", hour, got, want)
+		}
+	}
+	if got := heatmap.Busy[time.Sunday][23]; got != 30*time.Minute {
+		t.Errorf("busy time on Sunday at 23:00 = %v, want 30m", got)
+	}
+	if heatmap.Max(HeatmapBusy) != 60 || heatmap.Max(HeatmapJobs) != 2 {
+		t.Errorf("unexpected maxima: %v busy minutes, %v jobs", heatmap.Max(HeatmapBusy), heatmap.Max(HeatmapJobs))
+	}
+	if heatmap.CoveredHours() != 7*24 {
+		t.Errorf("expected a week to cover every hour, got %d", heatmap.CoveredHours())
+	}
+}
+
+func TestComputeLoadHeatmap_AveragesOverCoveredHours(t *testing.T) {
+	// Two weeks from Monday 2025-11-17, plus the first half of the third Monday at midnight
+	from := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC)
+	to := from.Add(14*24*time.Hour + 30*time.Minute)
+	jobs := []*entity.Job{
+		{StartedAt: ptrTime(from.Add(9 * time.Hour)), CompletedAt: ptrTime(from.Add(9*time.Hour + 40*time.Minute))},
+		{StartedAt: ptrTime(from.Add(7*24*time.Hour + 9*time.Hour)), CompletedAt: ptrTime(from.Add(7*24*time.Hour + 9*time.Hour + 20*time.Minute))},
+		{StartedAt: ptrTime(to.Add(-15 * time.Minute)), CompletedAt: ptrTime(to.Add(-5 * time.Minute))},
+	}
+
+	heatmap := ComputeLoadHeatmap(jobs, from, to, time.UTC)
+
+	if got := heatmap.Covered[time.Monday][9]; got != 2*time.Hour {
+		t.Errorf("expected Monday 09:00 to be covered twice, got %v", got)
+	}
+	if got := heatmap.Value(HeatmapBusy, time.Monday, 9); got != 30 {
+		t.Errorf("busy minutes on Monday at 09:00 = %v, want the average 30", got)
+	}
+	if got := heatmap.Value(HeatmapJobs, time.Monday, 9); got != 1 {
+		t.Errorf("jobs on Monday at 09:00 = %v, want the average 1", got)
+	}
+	// Covered for two and a half hours, busy for ten minutes
+	if got := heatmap.Value(HeatmapBusy, time.Monday, 0); got != 4 {
+		t.Errorf("busy minutes on Monday at 00:00 = %v, want 4", got)
+	}
+}
+
+func TestLoadHeatmap_QuietestWindow(t *testing.T) {
+	heatmap := &LoadHeatmap{}
+	for _, day := range Weekdays {
+		for hour := 0; hour < 24; hour++ {
+			heatmap.Busy[day][hour] = time.Hour
+			heatmap.Covered[day][hour] = time.Hour
+		}
+	}
+	// The quietest stretch wraps from Sunday night into Monday morning
+	heatmap.Busy[time.Sunday][23] = 0
+	heatmap.Busy[time.Monday][0] = 0
+	heatmap.Busy[time.Monday][1] = 10 * time.Minute
+	heatmap.Jobs[time.Monday][1] = 1
+
+	window, ok := heatmap.QuietestWindow(3)
+
+	if !ok || window.Weekday != time.Sunday || window.Hour != 23 || window.Hours != 3 {
+		t.Errorf("unexpected window start: %+v", window)
+	}
+	if window.Busy != 10*time.Minute || window.Jobs != 1 {
+		t.Errorf("unexpected window load: %+v", window)
+	}
+}
+
+func TestLoadHeatmap_QuietestWindowPrefersEarliest(t *testing.T) {
+	from := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC)
+	window, ok := ComputeLoadHeatmap(nil, from, from.Add(7*24*time.Hour), time.UTC).QuietestWindow(4)
+
+	if !ok || window.Weekday != time.Monday || window.Hour != 0 {
+		t.Errorf("expected the week to start on Monday at midnight, got %+v", window)
+	}
+}
+
+func TestLoadHeatmap_QuietestWindowSkipsUncoveredHours(t *testing.T) {
+	// A day from Friday 2025-11-21 at noon, busy but for Saturday 02:00 to 06:00
+	from := time.Date(2025, 11, 21, 12, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	jobs := []*entity.Job{
+		{StartedAt: ptrTime(from), CompletedAt: ptrTime(from.Add(14 * time.Hour))},
+		{StartedAt: ptrTime(from.Add(18 * time.Hour)), CompletedAt: ptrTime(to)},
+	}
+	heatmap := ComputeLoadHeatmap(jobs, from, to, time.UTC)
+
+	window, ok := heatmap.QuietestWindow(4)
+
+	if !ok || window.Weekday != time.Saturday || window.Hour != 2 || window.Busy != 0 {
+		t.Errorf("expected Saturday 02:00 to 06:00, got %+v", window)
+	}
+	if heatmap.CoveredHours() != 24 || heatmap.IsCovered(time.Monday, 0) {
+		t.Errorf("expected only the 24 hours of the window to be covered, got %d", heatmap.CoveredHours())
+	}
+	if _, ok := heatmap.QuietestWindow(25); ok {
+		t.Error("expected no window longer than the covered hours")
+	}
+}
+
+func TestParseHeatmapMetric(t *testing.T) {
+	if metric, err := ParseHeatmapMetric("jobs"); err != nil || metric != HeatmapJobs {
+		t.Errorf("ParseHeatmapMetric(jobs) = %v, %v", metric, err)
+	}
+	if _, err := ParseHeatmapMetric("minutes"); err == nil {
+		t.Error("expected an error for an unknown metric")
+	}
+}
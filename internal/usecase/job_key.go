@@ -0,0 +1,26 @@
+package usecase
+
+import "github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+
+// jobKey identifies a job across workflow runs by repository, workflow name and job name
+type jobKey struct {
+	repository string
+	workflow   string
+	name       string
+}
+
+// newJobKey returns the key of the job
+func newJobKey(job *entity.Job) jobKey {
+	return jobKey{repository: job.Repository, workflow: job.WorkflowName, name: job.Name}
+}
+
+// less orders keys by repository, then workflow name, then job name
+func (k jobKey) less(other jobKey) bool {
+	if k.repository != other.repository {
+		return k.repository < other.repository
+	}
+	if k.workflow != other.workflow {
+		return k.workflow < other.workflow
+	}
+	return k.name < other.name
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+)
+
+func TestJobKey(t *testing.T) {
+	build := newJobKey(&entity.Job{Repository: "acme/app", WorkflowName: "CI", Name: "build", RunID: 1})
+	if rerun := newJobKey(&entity.Job{Repository: "acme/app", WorkflowName: "CI", Name: "build", RunID: 2}); rerun != build {
+		t.Errorf("expected runs of the same job to share a key, got %+v and %+v", build, rerun)
+	}
+
+	ordered := []jobKey{
+		{repository: "acme/api", workflow: "Release", name: "publish"},
+		{repository: "acme/app", workflow: "CI", name: "test"},
+		{repository: "acme/app", workflow: "Deploy", name: "build"},
+		{repository: "acme/app", workflow: "Deploy", name: "push"},
+	}
+	for i := 1; i < len(ordered); i++ {
+		if !ordered[i-1].less(ordered[i]) || ordered[i].less(ordered[i-1]) {
+			t.Errorf("expected %+v before %+v", ordered[i-1], ordered[i])
+		}
+	}
+	if build.less(build) {
+		t.Error("expected a key not to be less than itself")
+	}
+}
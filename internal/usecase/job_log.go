@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+)
+
+// LogLineKind classifies a log line by the workflow command that prefixes it
+type LogLineKind int
+
+const (
+	LogLinePlain LogLineKind = iota
+	LogLineCommand
+	LogLineError
+	LogLineWarning
+	LogLineNotice
+	LogLineDebug
+)
+
+// MaxJobLogBytes caps the size of the log kept for a job; longer logs keep their end, where
+// failures are reported
+const MaxJobLogBytes = 8 << 20
+
+// logLineCommands maps the ##[...] prefixes GitHub writes into logs to line kinds
+var logLineCommands = map[string]LogLineKind{
+	"command": LogLineCommand,
+	"error":   LogLineError,
+	"warning": LogLineWarning,
+	"notice":  LogLineNotice,
+	"debug":   LogLineDebug,
+}
+
+// logTimestampPattern matches the timestamp the runner writes at the start of every log line
+var logTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+
+// LogLine is a line of a job log without its timestamp and workflow command prefix
+// Text keeps the ANSI escape sequences written by the job.
+type LogLine struct {
+	Text string
+	Kind LogLineKind
+}
+
+// LogSection is a single log line, or a ##[group] whose lines can be folded under its title
+type LogSection struct {
+	// Title is the line itself, or the title of the group
+	Title   LogLine
+	IsGroup bool
+	// Lines are the lines inside the group
+	Lines []LogLine
+}
+
+// JobLog is the parsed log of a job
+type JobLog struct {
+	Job      *entity.Job
+	Sections []LogSection
+	// Truncated reports whether the start of the log was dropped to stay within MaxJobLogBytes
+	Truncated bool
+}
+
+// FetchJobLog downloads the log of a job and splits it into lines and foldable groups
+// Only the whole lines within the last MaxJobLogBytes of the log are kept.
+func (r *RunnerLogger) FetchJobLog(ctx context.Context, job *entity.Job) (*JobLog, error) {
+	result, err := r.jobRepo.FetchJobLog(ctx, job, MaxJobLogBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch log for job %s: %w", job.Name, err)
+	}
+
+	text := result.Text
+	if result.Truncated {
+		// The log was cut at a byte offset; drop the partial first line
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			text = text[i+1:]
+		}
+	}
+	return &JobLog{Job: job, Sections: ParseJobLog(text), Truncated: result.Truncated}, nil
+}
+
+// ParseJobLog splits a raw job log into sections
+// Timestamps are dropped, lines between ##[group] and ##[endgroup] are collected under the group
+// title, and the other workflow command prefixes are turned into line kinds. Groups do not nest,
+// so a group left open ends at the next group.
+func ParseJobLog(text string) []LogSection {
+	text = strings.TrimPrefix(text, "\ufeff")
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+
+	var sections []LogSection
+	group := -1
+	for _, raw := range strings.Split(text, "\n") {
+		raw = logTimestampPattern.ReplaceAllString(raw, "")
+
+		if title, ok := strings.CutPrefix(raw, "##[group]"); ok {
+			sections = append(sections, LogSection{Title: LogLine{Text: title}, IsGroup: true})
+			group = len(sections) - 1
+			continue
+		}
+		if strings.HasPrefix(raw, "##[endgroup]") {
+			group = -1
+			continue
+		}
+
+		line := parseLogLine(raw)
+		if group >= 0 {
+			sections[group].Lines = append(sections[group].Lines, line)
+		} else {
+			sections = append(sections, LogSection{Title: line})
+		}
+	}
+	return sections
+}
+
+// parseLogLine turns a ##[kind] prefix into the kind of the line
+func parseLogLine(raw string) LogLine {
+	if rest, ok := strings.CutPrefix(raw, "##["); ok {
+		if command, text, ok := strings.Cut(rest, "]"); ok {
+			if kind, known := logLineCommands[command]; known {
+				return LogLine{Text: text, Kind: kind}
+			}
+		}
+	}
+	return LogLine{Text: raw}
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	testhelpers "github.com/VeyronSakai/gh-runner-log/test"
+)
+
+const testJobLog = "\ufeff2025-11-15T10:00:00.1234567Z Current runner version: '2.330.0'\r\n" +
+	"2025-11-15T10:00:00.2000000Z ##[group]Run actions/checkout@v4\r\n" +
+	"2025-11-15T10:00:00.3000000Z with:\r\n" +
+	"2025-11-15T10:00:00.4000000Z   fetch-depth: 1\r\n" +
+	"2025-11-15T10:00:00.5000000Z ##[endgroup]\r\n" +
+	"2025-11-15T10:00:01.0000000Z ##[command]/usr/bin/git version\r\n" +
+	"2025-11-15T10:00:02.0000000Z \x1b[32mok\x1b[0m\r\n" +
+	"2025-11-15T10:00:03.0000000Z ##[group]Run go test ./...\r\n" +
+	"2025-11-15T10:00:04.0000000Z --- FAIL: TestBuild\r\n" +
+	"2025-11-15T10:00:05.0000000Z ##[error]Process completed with exit code 1.\r\n"
+
+func TestParseJobLog(t *testing.T) {
+	sections := ParseJobLog(testJobLog)
+
+	if len(sections) != 5 {
+		t.Fatalf("expected 5 sections, got %d: %+v", len(sections), sections)
+	}
+	if s := sections[0]; s.IsGroup || s.Title.Text != "Current runner version: '2.330.0'" {
+		t.Errorf("unexpected first line: %+v", s)
+	}
+	checkout := sections[1]
+	if !checkout.IsGroup || checkout.Title.Text != "Run actions/checkout@v4" || len(checkout.Lines) != 2 || checkout.Lines[1].Text != "  fetch-depth: 1" {
+		t.Errorf("unexpected checkout group: %+v", checkout)
+	}
+	if s := sections[2]; s.Title.Kind != LogLineCommand || s.Title.Text != "/usr/bin/git version" {
+		t.Errorf("unexpected command line: %+v", s)
+	}
+	if s := sections[3]; s.Title.Text != "\x1b[32mok\x1b[0m" {
+		t.Errorf("expected colours to be kept, got %q", s.Title.Text)
+	}
+	// The last group is never closed
+	tests := sections[4]
+	if !tests.IsGroup || len(tests.Lines) != 2 || tests.Lines[1].Kind != LogLineError || tests.Lines[1].Text != "Process completed with exit code 1." {
+		t.Errorf("unexpected test group: %+v", tests)
+	}
+}
+
+func TestParseJobLog_Empty(t *testing.T) {
+	if sections := ParseJobLog(""); sections != nil {
+		t.Errorf("expected no sections, got %+v", sections)
+	}
+}
+
+func TestFetchJobLog_KeepsWholeLinesOfLongLogs(t *testing.T) {
+	job := &entity.Job{ID: 1, Name: "build"}
+	// Longer than the limit, so the kept text starts in the middle of the first line
+	text := "x" + strings.Repeat("line\n", MaxJobLogBytes/5)
+	repo := &testhelpers.StubJobRepository{Logs: map[int64]string{1: "first " + text}}
+
+	log, err := NewRunnerLogger(repo, &testhelpers.StubRunnerRepository{}).FetchJobLog(context.Background(), job)
+	if err != nil {
+		t.Fatalf("FetchJobLog error: %v", err)
+	}
+	if !log.Truncated || len(log.Sections) != MaxJobLogBytes/5-1 || log.Sections[0].Title.Text != "line" {
+		t.Errorf("expected only the whole lines at the end, got %d sections (truncated %v)", len(log.Sections), log.Truncated)
+	}
+}
+
+func TestFetchJobLog(t *testing.T) {
+	job := &entity.Job{ID: 1, Name: "build"}
+	repo := &testhelpers.StubJobRepository{Logs: map[int64]string{1: testJobLog}}
+
+	log, err := NewRunnerLogger(repo, &testhelpers.StubRunnerRepository{}).FetchJobLog(context.Background(), job)
+	if err != nil {
+		t.Fatalf("FetchJobLog error: %v", err)
+	}
+	if log.Job != job || len(log.Sections) != 5 {
+		t.Errorf("unexpected log: %+v", log)
+	}
+
+	repo.LogErr = errors.New("not found")
+	if _, err := NewRunnerLogger(repo, &testhelpers.StubRunnerRepository{}).FetchJobLog(context.Background(), job); err == nil || !strings.Contains(err.Error(), "failed to fetch log for job build: not found") {
+		t.Errorf("expected the repository error, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+)
+
+// JobStats summarizes the jobs of the selected runners over a time window
+type JobStats struct {
+	// From and To bound the window used for throughput
+	From time.Time
+	To   time.Time
+	// Total is the number of jobs, including unfinished ones
+	Total int
+	// Completed is the number of finished jobs
+	Completed int
+	// Conclusions counts finished jobs by conclusion
+	Conclusions map[string]int
+	// Concluded is the number of finished jobs that count towards the success and failure
+	// rates; skipped jobs are excluded
+	Concluded int
+	Succeeded int
+	// Failed counts jobs that failed or timed out
+	Failed int
+	// Durations holds execution time percentiles of the finished jobs
+	Durations DurationPercentiles
+	// QueueTimes holds percentiles of the time jobs waited for a runner, from creation to start
+	QueueTimes DurationPercentiles
+}
+
+// DurationPercentiles holds percentiles of a set of durations
+type DurationPercentiles struct {
+	// Count is the number of durations the percentiles were computed from
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+}
+
+// SuccessRate returns the fraction of concluded jobs that succeeded
+// The second return value is false when no jobs have concluded.
+func (s *JobStats) SuccessRate() (float64, bool) {
+	if s.Concluded == 0 {
+		return 0, false
+	}
+	return float64(s.Succeeded) / float64(s.Concluded), true
+}
+
+// FailureRate returns the fraction of concluded jobs that failed or timed out
+// The second return value is false when no jobs have concluded.
+func (s *JobStats) FailureRate() (float64, bool) {
+	if s.Concluded == 0 {
+		return 0, false
+	}
+	return float64(s.Failed) / float64(s.Concluded), true
+}
+
+// JobsPerHour returns the average number of jobs per hour over the window
+func (s *JobStats) JobsPerHour() float64 {
+	hours := s.To.Sub(s.From).Hours()
+	if hours <= 0 {
+		return 0
+	}
+	return float64(s.Total) / hours
+}
+
+// JobsPerDay returns the average number of jobs per day over the window
+func (s *JobStats) JobsPerDay() float64 {
+	return s.JobsPerHour() * 24
+}
+
+// RunnerStats is the statistics report for the selected runners
+type RunnerStats struct {
+	History *RunnerJobHistory
+	Stats   *JobStats
+}
+
+// FetchRunnerStats fetches every job the selected runners picked up since the given time
+// and computes statistics over the window from since until now
+func (r *RunnerLogger) FetchRunnerStats(ctx context.Context, selector RunnerSelector, opts HistoryOptions, since time.Time) (*RunnerStats, error) {
+	history, err := r.fetchWindowHistory(ctx, selector, opts, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunnerStats{
+		History: history,
+		Stats:   ComputeJobStats(history.Jobs, since, time.Now()),
+	}, nil
+}
+
+// ComputeJobStats computes statistics over the jobs for the window between from and to
+func ComputeJobStats(jobs []*entity.Job, from, to time.Time) *JobStats {
+	stats := &JobStats{
+		From:        from,
+		To:          to,
+		Total:       len(jobs),
+		Conclusions: make(map[string]int),
+	}
+
+	var durations, queueTimes []time.Duration
+	for _, job := range jobs {
+		if job.CreatedAt != nil && job.StartedAt != nil {
+			queueTimes = append(queueTimes, job.GetQueueDuration())
+		}
+		if !job.IsCompleted() {
+			continue
+		}
+
+		stats.Completed++
+		stats.Conclusions[job.Conclusion]++
+		if job.IsConcluded() {
+			stats.Concluded++
+		}
+		if job.IsSucceeded() {
+			stats.Succeeded++
+		}
+		if job.IsFailed() {
+			stats.Failed++
+		}
+		if job.StartedAt != nil && job.CompletedAt != nil {
+			durations = append(durations, job.GetExecutionDuration())
+		}
+	}
+
+	stats.Durations = ComputeDurationPercentiles(durations)
+	stats.QueueTimes = ComputeDurationPercentiles(queueTimes)
+	return stats
+}
+
+// ComputeDurationPercentiles computes the p50, p90 and p99 of the durations using the nearest-rank method
+func ComputeDurationPercentiles(durations []time.Duration) DurationPercentiles {
+	if len(durations) == 0 {
+		return DurationPercentiles{}
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return DurationPercentiles{
+		Count: len(sorted),
+		P50:   percentile(sorted, 50),
+		P90:   percentile(sorted, 90),
+		P99:   percentile(sorted, 99),
+	}
+}
+
+// percentile returns the p-th percentile of sorted durations using the nearest-rank method
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
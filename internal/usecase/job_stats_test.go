@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	testhelpers "github.com/VeyronSakai/gh-runner-log/test"
+)
+
+func TestComputeJobStats(t *testing.T) {
+	from := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	to := from.Add(48 * time.Hour)
+	completedJob := func(conclusion string, minutes int) *entity.Job {
+		started := from.Add(time.Hour)
+		completed := started.Add(time.Duration(minutes) * time.Minute)
+		return &entity.Job{Status: entity.StatusCompleted, Conclusion: conclusion, StartedAt: &started, CompletedAt: &completed}
+	}
+	jobs := []*entity.Job{
+		completedJob("success", 1),
+		completedJob("success", 2),
+		completedJob("success", 3),
+		completedJob("failure", 10),
+		completedJob("timed_out", 60),
+		completedJob("cancelled", 4),
+		completedJob("skipped", 0),
+		{Status: entity.StatusInProgress, StartedAt: &from},
+	}
+
+	stats := ComputeJobStats(jobs, from, to)
+
+	if stats.Total != 8 || stats.Completed != 7 || stats.Concluded != 6 {
+		t.Errorf("unexpected counts: total %d, completed %d, concluded %d", stats.Total, stats.Completed, stats.Concluded)
+	}
+	if stats.Conclusions["success"] != 3 || stats.Conclusions["skipped"] != 1 {
+		t.Errorf("unexpected conclusions: %v", stats.Conclusions)
+	}
+	if rate, ok := stats.SuccessRate(); !ok || rate != 0.5 {
+		t.Errorf("expected success rate 0.5, got %v (%v)", rate, ok)
+	}
+	// Timed out jobs count as failures
+	if rate, ok := stats.FailureRate(); !ok || rate != float64(2)/6 {
+		t.Errorf("expected failure rate 2/6, got %v (%v)", rate, ok)
+	}
+	if stats.Durations.Count != 7 || stats.Durations.P50 != 3*time.Minute || stats.Durations.P90 != time.Hour {
+		t.Errorf("unexpected duration percentiles: %+v", stats.Durations)
+	}
+	if stats.JobsPerHour() != 8.0/48 || stats.JobsPerDay() != 4 {
+		t.Errorf("unexpected throughput: %v/hour, %v/day", stats.JobsPerHour(), stats.JobsPerDay())
+	}
+}
+
+func TestComputeJobStats_QueueTimes(t *testing.T) {
+	from := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	queuedJob := func(wait time.Duration) *entity.Job {
+		started := from.Add(time.Hour)
+		return &entity.Job{Status: entity.StatusInProgress, CreatedAt: ptrTime(started.Add(-wait)), StartedAt: &started}
+	}
+	jobs := []*entity.Job{
+		queuedJob(10 * time.Second),
+		queuedJob(time.Minute),
+		queuedJob(5 * time.Minute),
+		// Jobs without a creation time or still waiting for a runner are not counted
+		{Status: entity.StatusInProgress, StartedAt: &from},
+		{Status: entity.StatusQueued, CreatedAt: &from},
+	}
+
+	stats := ComputeJobStats(jobs, from, from.Add(24*time.Hour))
+
+	if stats.QueueTimes.Count != 3 || stats.QueueTimes.P50 != time.Minute || stats.QueueTimes.P99 != 5*time.Minute {
+		t.Errorf("unexpected queue time percentiles: %+v", stats.QueueTimes)
+	}
+}
+
+func TestComputeJobStats_NoJobs(t *testing.T) {
+	now := time.Now()
+	stats := ComputeJobStats(nil, now, now)
+
+	if _, ok := stats.SuccessRate(); ok {
+		t.Error("expected no success rate without jobs")
+	}
+	if stats.Durations.Count != 0 || stats.JobsPerHour() != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestComputeDurationPercentiles(t *testing.T) {
+	durations := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Second)
+	}
+
+	p := ComputeDurationPercentiles(durations)
+	if p.P50 != 50*time.Second || p.P90 != 90*time.Second || p.P99 != 99*time.Second {
+		t.Errorf("unexpected percentiles: %+v", p)
+	}
+	if durations[0] != 100*time.Second {
+		t.Error("expected the input to be left unsorted")
+	}
+
+	single := ComputeDurationPercentiles([]time.Duration{time.Minute})
+	if single.P50 != time.Minute || single.P99 != time.Minute {
+		t.Errorf("unexpected percentiles for a single duration: %+v", single)
+	}
+}
+
+func TestFetchRunnerStats_UsesEveryJobInWindow(t *testing.T) {
+	runner := &entity.Runner{ID: 7, Name: "runner"}
+	jobs := make([]*entity.Job, 0, 30)
+	for i := 0; i < 30; i++ {
+		started := time.Date(2025, 11, 16, 0, i, 0, 0, time.UTC)
+		jobs = append(jobs, &entity.Job{ID: int64(i), RunnerID: ptrInt64(7), StartedAt: &started})
+	}
+	jobRepo := &testhelpers.StubJobRepository{Jobs: jobs}
+
+	runnerLogger := NewRunnerLogger(jobRepo, &testhelpers.StubRunnerRepository{Runner: runner})
+	since := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	report, err := runnerLogger.FetchRunnerStats(context.Background(), RunnerSelector{Names: []string{"runner"}}, HistoryOptions{Limit: 5}, since)
+	if err != nil {
+		t.Fatalf("FetchRunnerStats error: %v", err)
+	}
+
+	if jobRepo.LastQuery.Limit != 0 {
+		t.Errorf("expected no job limit, got %d", jobRepo.LastQuery.Limit)
+	}
+	if report.Stats.Total != 30 || !report.Stats.From.Equal(since) {
+		t.Errorf("unexpected stats: %+v", report.Stats)
+	}
+}
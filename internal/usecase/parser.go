@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -52,3 +54,53 @@ func parseDuration(s string) (time.Duration, error) {
 
 	return 0, fmt.Errorf("invalid duration format: %s", s)
 }
+
+// CompileRunnerPattern compiles a runner name pattern into a regular expression
+// By default the pattern is a glob ('*' matches any characters, '?' a single character,
+// '[...]' a character class) that must match the whole name; with regex set it is used as-is.
+func CompileRunnerPattern(pattern string, regex bool) (*regexp.Regexp, error) {
+	if regex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid runner name regular expression: %w", err)
+		}
+		return re, nil
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid runner name pattern %q: unterminated character class", pattern)
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			// Quote the whole literal run so that multi-byte characters stay intact
+			end := strings.IndexAny(pattern[i:], "*?[")
+			if end < 0 {
+				end = len(pattern) - i
+			}
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+end]))
+			i += end - 1
+		}
+	}
+	b.WriteString("$")
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid runner name pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
@@ -240,3 +240,100 @@ func TestParseSince_DurationRelativeToNow(t *testing.T) {
 		t.Errorf("ParseSince('1h') = %v, expected between %v and %v", result, expectedMin, expectedMax)
 	}
 }
+
+func TestCompileRunnerPattern(t *testing.T) {
+	tests := []struct {
+		name        string
+		pattern     string
+		regex       bool
+		matches     []string
+		nonMatches  []string
+		expectError bool
+	}{
+		{
+			name:       "glob with star",
+			pattern:    "ci-ephemeral-*",
+			matches:    []string{"ci-ephemeral-", "ci-ephemeral-abc123"},
+			nonMatches: []string{"ci-ephemeral", "x-ci-ephemeral-1"},
+		},
+		{
+			name:       "glob with question mark and class",
+			pattern:    "gpu-[0-9]?",
+			matches:    []string{"gpu-1a", "gpu-99"},
+			nonMatches: []string{"gpu-a1", "gpu-1", "gpu-123"},
+		},
+		{
+			name:       "glob with negated class",
+			pattern:    "pool-[!b]",
+			matches:    []string{"pool-a"},
+			nonMatches: []string{"pool-b"},
+		},
+		{
+			name:       "glob escapes regexp metacharacters",
+			pattern:    "runner.1+",
+			matches:    []string{"runner.1+"},
+			nonMatches: []string{"runnerx11"},
+		},
+		{
+			name:       "glob with non-ASCII characters",
+			pattern:    "bü-*",
+			matches:    []string{"bü-1", "bü-"},
+			nonMatches: []string{"bu-1", "xbü-1"},
+		},
+		{
+			name:       "glob with non-ASCII characters around wildcards",
+			pattern:    "ランナー?-é",
+			matches:    []string{"ランナー1-é", "ランナーü-é"},
+			nonMatches: []string{"ランナー-é", "ランナー12-é"},
+		},
+		{
+			name:       "plain name matches exactly",
+			pattern:    "runner-a",
+			matches:    []string{"runner-a"},
+			nonMatches: []string{"runner-ab"},
+		},
+		{
+			name:       "regular expression is not anchored",
+			pattern:    `ephemeral-\d+$`,
+			regex:      true,
+			matches:    []string{"ci-ephemeral-42"},
+			nonMatches: []string{"ci-ephemeral-x"},
+		},
+		{
+			name:        "unterminated class",
+			pattern:     "runner-[ab",
+			expectError: true,
+		},
+		{
+			name:        "invalid regular expression",
+			pattern:     "runner-(",
+			regex:       true,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := CompileRunnerPattern(tt.pattern, tt.regex)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("CompileRunnerPattern(%q) expected error, got nil", tt.pattern)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompileRunnerPattern(%q) unexpected error: %v", tt.pattern, err)
+			}
+			for _, name := range tt.matches {
+				if !re.MatchString(name) {
+					t.Errorf("expected %q to match %q", tt.pattern, name)
+				}
+			}
+			for _, name := range tt.nonMatches {
+				if re.MatchString(name) {
+					t.Errorf("expected %q not to match %q", tt.pattern, name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+)
+
+// Defaults for duration regression detection
+const (
+	DefaultBaselineRuns     = 10
+	DefaultRegressionFactor = 1.5
+	// MinBaselineRuns is the smallest RegressionOptions.BaselineRuns accepted
+	MinBaselineRuns = 3
+)
+
+// RegressionOptions controls how duration regressions are detected
+type RegressionOptions struct {
+	// BaselineRuns is the number of preceding successful runs of a job whose median is its baseline
+	BaselineRuns int
+	// Factor flags runs that took longer than Factor times the baseline
+	Factor float64
+	// Recent only flags runs that started at or after it, so that earlier runs only serve as
+	// baselines; zero flags runs anywhere in the history
+	Recent time.Time
+}
+
+// DurationRegression is a successful run of a job that took much longer than its baseline
+type DurationRegression struct {
+	Job *entity.Job
+	// Baseline is the median execution time of the preceding successful runs of the same job
+	Baseline time.Duration
+}
+
+// Ratio returns how many times longer than the baseline the run took
+func (r DurationRegression) Ratio() float64 {
+	if r.Baseline <= 0 {
+		return 0
+	}
+	return float64(r.Job.GetExecutionDuration()) / float64(r.Baseline)
+}
+
+// JobRegressions groups the duration regressions of a job, as keyed by newJobKey
+type JobRegressions struct {
+	Repository   string
+	WorkflowName string
+	Name         string
+	// Runs is the number of successful runs of the job
+	Runs int
+	// Baseline is the median execution time of the most recent successful runs
+	Baseline time.Duration
+	// Regressions are sorted by start time, most recent first
+	Regressions []DurationRegression
+}
+
+// key returns the key of the job the regressions belong to
+func (j *JobRegressions) key() jobKey {
+	return jobKey{repository: j.Repository, workflow: j.WorkflowName, name: j.Name}
+}
+
+// RegressionReport lists the jobs of the selected runners whose recent runs took much longer
+// than usual
+type RegressionReport struct {
+	History *RunnerJobHistory
+	From    time.Time
+	To      time.Time
+	Options RegressionOptions
+	// Jobs are the jobs with at least one regression, sorted by number of regressions, most first
+	Jobs []*JobRegressions
+}
+
+// FetchDurationRegressions fetches every job the selected runners picked up since the given
+// time and flags successful runs that took much longer than the preceding runs of the same job
+func (r *RunnerLogger) FetchDurationRegressions(ctx context.Context, selector RunnerSelector, opts HistoryOptions, since time.Time, regressionOpts RegressionOptions) (*RegressionReport, error) {
+	if regressionOpts.BaselineRuns < MinBaselineRuns {
+		return nil, fmt.Errorf("the baseline needs at least %d runs", MinBaselineRuns)
+	}
+	if regressionOpts.Factor <= 1 {
+		return nil, fmt.Errorf("the regression factor must be greater than 1")
+	}
+	if !regressionOpts.Recent.IsZero() && regressionOpts.Recent.Before(since) {
+		return nil, fmt.Errorf("the recent runs must start within the time window")
+	}
+
+	history, err := r.fetchWindowHistory(ctx, selector, opts, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegressionReport{
+		History: history,
+		From:    since,
+		To:      time.Now(),
+		Options: regressionOpts,
+		Jobs:    DetectDurationRegressions(history.Jobs, regressionOpts),
+	}, nil
+}
+
+// DetectDurationRegressions walks the successful runs of each job in the order they started and
+// flags every run that took longer than opts.Factor times the median of the preceding
+// opts.BaselineRuns runs
+// Runs with fewer than opts.BaselineRuns earlier runs have no full baseline and are never flagged,
+// nor are runs that started before opts.Recent.
+func DetectDurationRegressions(jobs []*entity.Job, opts RegressionOptions) []*JobRegressions {
+	runs := make(map[jobKey][]*entity.Job)
+	var keys []jobKey
+	for _, job := range jobs {
+		if !job.IsSucceeded() || job.StartedAt == nil || job.CompletedAt == nil {
+			continue
+		}
+		key := newJobKey(job)
+		if _, ok := runs[key]; !ok {
+			keys = append(keys, key)
+		}
+		runs[key] = append(runs[key], job)
+	}
+
+	var result []*JobRegressions
+	for _, key := range keys {
+		jobRuns := runs[key]
+		sort.SliceStable(jobRuns, func(i, j int) bool { return jobRuns[i].StartedAt.Before(*jobRuns[j].StartedAt) })
+
+		job := &JobRegressions{Repository: key.repository, WorkflowName: key.workflow, Name: key.name, Runs: len(jobRuns)}
+		for i, run := range jobRuns {
+			if i < opts.BaselineRuns || run.StartedAt.Before(opts.Recent) {
+				continue
+			}
+			baseline := rollingBaseline(jobRuns[max(0, i-opts.BaselineRuns):i])
+			if float64(run.GetExecutionDuration()) > float64(baseline)*opts.Factor {
+				job.Regressions = append(job.Regressions, DurationRegression{Job: run, Baseline: baseline})
+			}
+		}
+		if len(job.Regressions) == 0 {
+			continue
+		}
+
+		job.Baseline = rollingBaseline(jobRuns[max(0, len(jobRuns)-opts.BaselineRuns):])
+		sort.SliceStable(job.Regressions, func(i, j int) bool {
+			return startedAfter(job.Regressions[i].Job, job.Regressions[j].Job)
+		})
+		result = append(result, job)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if len(a.Regressions) != len(b.Regressions) {
+			return len(a.Regressions) > len(b.Regressions)
+		}
+		return a.key().less(b.key())
+	})
+	return result
+}
+
+// rollingBaseline returns the median execution time of the runs
+func rollingBaseline(runs []*entity.Job) time.Duration {
+	durations := make([]time.Duration, len(runs))
+	for i, run := range runs {
+		durations[i] = run.GetExecutionDuration()
+	}
+	return ComputeDurationPercentiles(durations).P50
+}
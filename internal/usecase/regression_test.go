@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	testhelpers "github.com/VeyronSakai/gh-runner-log/test"
+)
+
+func TestDetectDurationRegressions(t *testing.T) {
+	runs := []struct {
+		name       string
+		conclusion string
+		duration   time.Duration
+	}{
+		// Too few earlier runs to have a baseline, so the slow first runs are never flagged
+		{"build", "success", 10 * time.Minute},
+		{"build", "success", 4 * time.Minute},
+		{"build", "success", 4 * time.Minute},
+		{"build", "success", 4 * time.Minute},
+		// Baseline 4m: 5m is within the factor, 7m is not
+		{"build", "success", 5 * time.Minute},
+		{"build", "success", 7 * time.Minute},
+		// Failed runs neither count towards the baseline nor get flagged
+		{"build", "failure", 30 * time.Minute},
+		// The baseline only covers the last 4 runs: 4m, 4m, 5m and 7m
+		{"build", "success", 6 * time.Minute},
+		{"build", "success", 9 * time.Minute},
+		// A job without regressions is not reported
+		{"lint", "success", time.Minute},
+		{"lint", "success", time.Minute},
+		{"lint", "success", time.Minute},
+		{"lint", "success", time.Minute},
+	}
+	// Run i starts i hours into the window and gets ID i
+	jobs := make([]*entity.Job, len(runs))
+	for i, run := range runs {
+		started := time.Date(2025, 11, 16, i, 0, 0, 0, time.UTC)
+		completed := started.Add(run.duration)
+		jobs[i] = &entity.Job{
+			ID:           int64(i),
+			RunID:        int64(i),
+			Name:         run.name,
+			Status:       entity.StatusCompleted,
+			Conclusion:   run.conclusion,
+			StartedAt:    &started,
+			CompletedAt:  &completed,
+			WorkflowName: "CI",
+		}
+	}
+	// Jobs arrive newest first, the order is not relied on
+	entity.SortByStartedAtDesc(jobs)
+
+	result := DetectDurationRegressions(jobs, RegressionOptions{BaselineRuns: 4, Factor: 1.5})
+
+	if len(result) != 1 {
+		t.Fatalf("expected 1 job with regressions, got %d", len(result))
+	}
+	build := result[0]
+	if build.Name != "build" || build.Runs != 8 || build.Baseline != 6*time.Minute {
+		t.Errorf("unexpected job: %+v", build)
+	}
+	if len(build.Regressions) != 2 {
+		t.Fatalf("expected 2 regressions, got %+v", build.Regressions)
+	}
+	// Most recent first: run 8 against 5m (4m, 5m, 7m, 6m), run 5 against 4m
+	if r := build.Regressions[0]; r.Job.ID != 8 || r.Baseline != 5*time.Minute || r.Ratio() != 1.8 {
+		t.Errorf("unexpected first regression: job %d, baseline %s, ratio %v", r.Job.ID, r.Baseline, r.Ratio())
+	}
+	if r := build.Regressions[1]; r.Job.ID != 5 || r.Baseline != 4*time.Minute {
+		t.Errorf("unexpected second regression: job %d, baseline %s", r.Job.ID, r.Baseline)
+	}
+}
+
+func TestDetectDurationRegressions_FullBaselineAndRecent(t *testing.T) {
+	durations := []time.Duration{
+		4 * time.Minute,
+		4 * time.Minute,
+		4 * time.Minute,
+		// Only 3 earlier runs: no full baseline of 4 runs yet
+		9 * time.Minute,
+		// Full baseline, but before the recent runs
+		9 * time.Minute,
+		4 * time.Minute,
+		20 * time.Minute,
+	}
+	jobs := make([]*entity.Job, len(durations))
+	for i, duration := range durations {
+		started := time.Date(2025, 11, 16, i, 0, 0, 0, time.UTC)
+		completed := started.Add(duration)
+		jobs[i] = &entity.Job{
+			ID:           int64(i),
+			RunID:        int64(i),
+			Name:         "build",
+			Status:       entity.StatusCompleted,
+			Conclusion:   "success",
+			StartedAt:    &started,
+			CompletedAt:  &completed,
+			WorkflowName: "CI",
+		}
+	}
+
+	recent := *jobs[5].StartedAt
+	result := DetectDurationRegressions(jobs, RegressionOptions{BaselineRuns: 4, Factor: 1.5, Recent: recent})
+
+	if len(result) != 1 || len(result[0].Regressions) != 1 || result[0].Regressions[0].Job.ID != 6 {
+		t.Fatalf("expected only run 6 to be flagged, got %+v", result)
+	}
+	if result[0].Runs != 7 {
+		t.Errorf("runs before the recent window should still be counted, got %d", result[0].Runs)
+	}
+}
+
+func TestFetchDurationRegressions_ValidatesOptions(t *testing.T) {
+	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{}, &testhelpers.StubRunnerRepository{})
+	since := time.Date(2025, 11, 15, 0, 0, 0, 0, time.UTC)
+	selector := RunnerSelector{Names: []string{"runner-a"}}
+
+	for _, opts := range []RegressionOptions{
+		{BaselineRuns: MinBaselineRuns - 1, Factor: 2},
+		{BaselineRuns: DefaultBaselineRuns, Factor: 1},
+		{BaselineRuns: DefaultBaselineRuns, Factor: 2, Recent: since.Add(-time.Hour)},
+	} {
+		if _, err := runnerLogger.FetchDurationRegressions(context.Background(), selector, HistoryOptions{}, since, opts); err == nil {
+			t.Errorf("expected an error for %+v", opts)
+		}
+	}
+}
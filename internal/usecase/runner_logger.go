@@ -3,7 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
-	"sort"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
@@ -23,45 +25,248 @@ func NewRunnerLogger(jobRepo repository.JobRepository, runnerRepo repository.Run
 	}
 }
 
-// RunnerJobHistory represents the job history for a specific runner
+// RunnerSelector describes which runners' job history to fetch
+type RunnerSelector struct {
+	// Names are runner names; a name containing glob characters ('*', '?', '[')
+	// selects every registered runner it matches
+	Names []string
+	// Group selects every runner in the named runner group
+	Group string
+	// Labels narrows the selection to runners that have all of these labels.
+	// Without Names or Group, every registered runner with the labels is selected.
+	Labels []string
+	// MatchJobsByName skips the runners API and selects jobs by the runner name recorded on
+	// each job, treating Names as patterns (see CompileRunnerPattern).
+	// This finds the history of ephemeral or deleted runners that are no longer registered.
+	MatchJobsByName bool
+	// Regex interprets Names as regular expressions instead of globs when MatchJobsByName is set
+	Regex bool
+}
+
+// HistoryOptions controls how runner job history is fetched
+type HistoryOptions struct {
+	// Limit is the maximum number of jobs to return; 0 means every job in the time window
+	Limit int
+	// Strict fails the whole fetch when the jobs of any workflow run cannot be retrieved
+	Strict bool
+}
+
+// RunnerJobHistory represents the merged job history of the selected runners
 type RunnerJobHistory struct {
-	Runner *entity.Runner
-	Jobs   []*entity.Job
+	// Runners are the selected registered runners; empty when jobs were matched by RunnerPattern
+	Runners []*entity.Runner
+	// RunnerPattern is the runner name pattern used with RunnerSelector.MatchJobsByName
+	RunnerPattern string
+	Jobs          []*entity.Job
+	// Failures lists workflow runs whose jobs could not be fetched
+	Failures []repository.RunFailure
+}
+
+// IsPartial reports whether some workflow runs are missing from the history
+func (h *RunnerJobHistory) IsPartial() bool {
+	return len(h.Failures) > 0
+}
+
+// IsMultiRunner reports whether the jobs may come from more than one runner
+func (h *RunnerJobHistory) IsMultiRunner() bool {
+	return len(h.Runners) != 1
 }
 
-// FetchRunnerJobHistory fetches job history for a specific runner
-func (r *RunnerLogger) FetchRunnerJobHistory(ctx context.Context, runnerName string, limit int) (*RunnerJobHistory, error) {
-	// First, fetch the runner to get its ID
-	runner, err := r.runnerRepo.FetchRunnerByName(ctx, runnerName)
+// SingleRunner returns the runner when exactly one runner was selected, otherwise nil
+func (h *RunnerJobHistory) SingleRunner() *entity.Runner {
+	if len(h.Runners) != 1 {
+		return nil
+	}
+	return h.Runners[0]
+}
+
+// RunnerOf returns the selected runner that ran the job, or nil if it is not one of Runners
+func (h *RunnerJobHistory) RunnerOf(job *entity.Job) *entity.Runner {
+	for _, runner := range h.Runners {
+		if job.IsAssignedToRunner(runner.ID) {
+			return runner
+		}
+	}
+	return nil
+}
+
+// FetchRunnerJobHistory fetches the merged job history of the selected runners
+func (r *RunnerLogger) FetchRunnerJobHistory(ctx context.Context, selector RunnerSelector, opts HistoryOptions) (*RunnerJobHistory, error) {
+	history, query, err := r.selectRunners(ctx, selector)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch runner: %w", err)
+		return nil, err
 	}
+	query.Limit = opts.Limit
+	query.Strict = opts.Strict
 
-	// Fetch job history filtered by runner ID
-	// The repository will paginate and filter until it gets enough jobs for this runner
-	jobs, err := r.jobRepo.FetchJobHistory(ctx, runner.ID)
+	// Fetch job history filtered by runner
+	// The repository will paginate and filter until it gets enough jobs for these runners
+	result, err := r.jobRepo.FetchJobHistory(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch job history: %w", err)
 	}
 
-	// Sort jobs by start time (most recent first)
-	sort.Slice(jobs, func(i, j int) bool {
-		if jobs[i].StartedAt == nil {
-			return false
+	// Repositories return jobs newest first, but enforce the ordering and limit here as well
+	jobs := result.Jobs
+	entity.SortByStartedAtDesc(jobs)
+	if opts.Limit > 0 && len(jobs) > opts.Limit {
+		jobs = jobs[:opts.Limit]
+	}
+
+	history.Jobs = jobs
+	history.Failures = result.Failures
+	return history, nil
+}
+
+// selectRunners resolves the selector into an empty history describing the selected runners
+// and a job query whose runner filters match their jobs
+func (r *RunnerLogger) selectRunners(ctx context.Context, selector RunnerSelector) (*RunnerJobHistory, repository.JobQuery, error) {
+	history := &RunnerJobHistory{}
+	var query repository.JobQuery
+
+	if selector.MatchJobsByName {
+		pattern, err := compileRunnerPatterns(selector.Names, selector.Regex)
+		if err != nil {
+			return nil, query, err
 		}
-		if jobs[j].StartedAt == nil {
-			return true
+		query.RunnerNamePattern = pattern
+		history.RunnerPattern = strings.Join(selector.Names, ", ")
+		return history, query, nil
+	}
+
+	// Resolve the runners to get their IDs
+	runners, err := r.resolveRunners(ctx, selector)
+	if err != nil {
+		return nil, query, fmt.Errorf("failed to fetch runner: %w", err)
+	}
+	history.Runners = runners
+	for _, runner := range runners {
+		query.RunnerIDs = append(query.RunnerIDs, runner.ID)
+	}
+	return history, query, nil
+}
+
+// fetchWindowHistory fetches every job the selected runners picked up since the given time
+func (r *RunnerLogger) fetchWindowHistory(ctx context.Context, selector RunnerSelector, opts HistoryOptions, since time.Time) (*RunnerJobHistory, error) {
+	if since.IsZero() {
+		return nil, fmt.Errorf("the start of the time window is required")
+	}
+
+	// Reports cover the whole window, not just the most recent jobs
+	opts.Limit = 0
+	return r.FetchRunnerJobHistory(ctx, selector, opts)
+}
+
+// resolveRunners looks up the registered runners chosen by the selector, without duplicates
+func (r *RunnerLogger) resolveRunners(ctx context.Context, selector RunnerSelector) ([]*entity.Runner, error) {
+	var runners []*entity.Runner
+	seen := make(map[int64]bool)
+	add := func(runner *entity.Runner) {
+		if !seen[runner.ID] {
+			seen[runner.ID] = true
+			runners = append(runners, runner)
 		}
-		return jobs[i].StartedAt.After(*jobs[j].StartedAt)
-	})
+	}
+
+	if selector.Group != "" {
+		groupRunners, err := r.runnerRepo.FetchRunnersInGroup(ctx, selector.Group)
+		if err != nil {
+			return nil, err
+		}
+		if len(groupRunners) == 0 {
+			return nil, fmt.Errorf("runner group '%s' has no runners", selector.Group)
+		}
+		for _, runner := range groupRunners {
+			add(runner)
+		}
+	}
+
+	// All runners are listed at most once, and only when a glob or label filter needs them
+	var allRunners []*entity.Runner
+	listRunners := func() ([]*entity.Runner, error) {
+		if allRunners == nil {
+			var err error
+			if allRunners, err = r.runnerRepo.FetchRunners(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return allRunners, nil
+	}
+
+	for _, name := range selector.Names {
+		if !isGlob(name) {
+			runner, err := r.runnerRepo.FetchRunnerByName(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			add(runner)
+			continue
+		}
+
+		candidates, err := listRunners()
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := CompileRunnerPattern(name, false)
+		if err != nil {
+			return nil, err
+		}
+		matched := false
+		for _, runner := range candidates {
+			if pattern.MatchString(runner.Name) {
+				add(runner)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no runners match '%s'", name)
+		}
+	}
+
+	if len(selector.Labels) > 0 {
+		candidates := runners
+		if len(selector.Names) == 0 && selector.Group == "" {
+			var err error
+			if candidates, err = listRunners(); err != nil {
+				return nil, err
+			}
+		}
+
+		runners = nil
+		for _, runner := range candidates {
+			if runner.HasLabels(selector.Labels...) {
+				runners = append(runners, runner)
+			}
+		}
+		if len(runners) == 0 {
+			return nil, fmt.Errorf("no runners have labels: %s", strings.Join(selector.Labels, ", "))
+		}
+	}
 
-	// Apply limit after sorting
-	if len(jobs) > limit {
-		jobs = jobs[:limit]
+	if len(runners) == 0 {
+		return nil, fmt.Errorf("no runners selected")
 	}
+	return runners, nil
+}
+
+// compileRunnerPatterns compiles several runner name patterns into one that matches any of them
+func compileRunnerPatterns(patterns []string, regex bool) (*regexp.Regexp, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("no runner name pattern given")
+	}
+
+	alternatives := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := CompileRunnerPattern(pattern, regex)
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, "(?:"+re.String()+")")
+	}
+	return regexp.Compile(strings.Join(alternatives, "|"))
+}
 
-	return &RunnerJobHistory{
-		Runner: runner,
-		Jobs:   jobs,
-	}, nil
+// isGlob reports whether a runner name contains glob characters
+func isGlob(name string) bool {
+	return strings.ContainsAny(name, "*?[")
 }
@@ -3,10 +3,12 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
 	testhelpers "github.com/VeyronSakai/gh-runner-log/test"
 )
 
@@ -23,7 +25,7 @@ func TestFetchRunnerJobHistoryFiltersAndOrdersJobs(t *testing.T) {
 	}
 
 	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{Jobs: jobs}, &testhelpers.StubRunnerRepository{Runner: runner})
-	history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), "runner-1", 5)
+	history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Names: []string{"runner-1"}}, HistoryOptions{Limit: 5})
 	if err != nil {
 		t.Fatalf("FetchRunnerJobHistory error: %v", err)
 	}
@@ -48,7 +50,7 @@ func TestFetchRunnerJobHistory_PrunesByLimit(t *testing.T) {
 	}
 
 	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{Jobs: jobs}, &testhelpers.StubRunnerRepository{Runner: runner})
-	history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), "runner", 5)
+	history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Names: []string{"runner"}}, HistoryOptions{Limit: 5})
 	if err != nil {
 		t.Fatalf("FetchRunnerJobHistory error: %v", err)
 	}
@@ -66,7 +68,7 @@ func TestFetchRunnerJobHistory_PropagatesRunnerError(t *testing.T) {
 	expected := errors.New("runner missing")
 	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{}, &testhelpers.FailingRunnerRepository{Err: expected})
 
-	_, err := runnerLogger.FetchRunnerJobHistory(context.Background(), "runner", 1)
+	_, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Names: []string{"runner"}}, HistoryOptions{Limit: 1})
 	if !errors.Is(err, expected) {
 		t.Fatalf("expected runner error, got %v", err)
 	}
@@ -77,12 +79,236 @@ func TestFetchRunnerJobHistory_PropagatesJobError(t *testing.T) {
 	expected := errors.New("jobs fail")
 	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{Err: expected}, &testhelpers.StubRunnerRepository{Runner: runner})
 
-	_, err := runnerLogger.FetchRunnerJobHistory(context.Background(), "runner", 1)
+	_, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Names: []string{"runner"}}, HistoryOptions{Limit: 1})
 	if !errors.Is(err, expected) {
 		t.Fatalf("expected job error, got %v", err)
 	}
 }
 
+func TestFetchRunnerJobHistory_ReportsPartialFailures(t *testing.T) {
+	runner := &entity.Runner{ID: 1, Name: "runner"}
+	started := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
+	failure := repository.RunFailure{RunID: 99, Repository: "owner/repo", Err: errors.New("boom")}
+	jobRepo := &testhelpers.StubJobRepository{
+		Jobs:     []*entity.Job{{ID: 1, RunnerID: ptrInt64(1), StartedAt: &started}},
+		Failures: []repository.RunFailure{failure},
+	}
+
+	runnerLogger := NewRunnerLogger(jobRepo, &testhelpers.StubRunnerRepository{Runner: runner})
+	history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Names: []string{"runner"}}, HistoryOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("FetchRunnerJobHistory error: %v", err)
+	}
+	if !history.IsPartial() {
+		t.Fatal("expected history to be partial")
+	}
+	if len(history.Jobs) != 1 || history.Failures[0].RunID != 99 {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+}
+
+func TestFetchRunnerJobHistory_StrictFailsOnPartialFailures(t *testing.T) {
+	runner := &entity.Runner{ID: 1, Name: "runner"}
+	expected := errors.New("boom")
+	jobRepo := &testhelpers.StubJobRepository{
+		Failures: []repository.RunFailure{{RunID: 99, Err: expected}},
+	}
+
+	runnerLogger := NewRunnerLogger(jobRepo, &testhelpers.StubRunnerRepository{Runner: runner})
+	_, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Names: []string{"runner"}}, HistoryOptions{Limit: 10, Strict: true})
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected strict failure, got %v", err)
+	}
+	if !jobRepo.LastQuery.Strict {
+		t.Error("expected Strict to be passed to the repository")
+	}
+}
+
+func TestFetchRunnerJobHistory_MatchRunnerName(t *testing.T) {
+	started := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
+	jobs := []*entity.Job{
+		{ID: 1, RunnerName: ptrString("ci-ephemeral-a1"), StartedAt: &started},
+		{ID: 2, RunnerName: ptrString("ci-static-1"), StartedAt: &started},
+		{ID: 3, RunnerName: ptrString("ci-ephemeral-b2"), StartedAt: &started},
+		{ID: 4, RunnerName: nil, StartedAt: &started},
+	}
+
+	// The runners API would fail because ephemeral runners are deregistered
+	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{Jobs: jobs}, &testhelpers.FailingRunnerRepository{Err: errors.New("not found")})
+	history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Names: []string{"ci-ephemeral-*"}, MatchJobsByName: true}, HistoryOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("FetchRunnerJobHistory error: %v", err)
+	}
+
+	if len(history.Runners) != 0 || history.RunnerPattern != "ci-ephemeral-*" || !history.IsMultiRunner() {
+		t.Errorf("unexpected runner information: %+v", history)
+	}
+	if len(history.Jobs) != 2 || history.Jobs[0].ID != 1 || history.Jobs[1].ID != 3 {
+		t.Fatalf("expected jobs 1 and 3, got %+v", history.Jobs)
+	}
+}
+
+func TestFetchRunnerJobHistory_MergesSelectedRunners(t *testing.T) {
+	runners := []*entity.Runner{
+		{ID: 1, Name: "linux-a"},
+		{ID: 2, Name: "linux-b"},
+		{ID: 3, Name: "mac-a"},
+		{ID: 4, Name: "windows-a"},
+	}
+	start := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	jobs := []*entity.Job{
+		{ID: 10, RunnerID: ptrInt64(1), StartedAt: &start},
+		{ID: 11, RunnerID: ptrInt64(2), StartedAt: ptrTime(start.Add(time.Minute))},
+		{ID: 12, RunnerID: ptrInt64(3), StartedAt: ptrTime(start.Add(2 * time.Minute))},
+		{ID: 13, RunnerID: ptrInt64(4), StartedAt: ptrTime(start.Add(3 * time.Minute))},
+	}
+
+	jobRepo := &testhelpers.StubJobRepository{Jobs: jobs}
+	runnerLogger := NewRunnerLogger(jobRepo, &testhelpers.StubRunnerRepository{Runners: runners})
+	// linux-a is matched by both the glob and its name but must only be selected once
+	selector := RunnerSelector{Names: []string{"linux-*", "mac-a", "linux-a"}}
+	history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), selector, HistoryOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("FetchRunnerJobHistory error: %v", err)
+	}
+
+	if len(history.Runners) != 3 || !history.IsMultiRunner() || history.SingleRunner() != nil {
+		t.Fatalf("expected 3 runners, got %+v", history.Runners)
+	}
+	if len(jobRepo.LastQuery.RunnerIDs) != 3 {
+		t.Errorf("expected 3 runner IDs in the query, got %v", jobRepo.LastQuery.RunnerIDs)
+	}
+	if len(history.Jobs) != 3 || history.Jobs[0].ID != 12 || history.Jobs[2].ID != 10 {
+		t.Fatalf("expected jobs 12, 11, 10, got %+v", history.Jobs)
+	}
+	if runner := history.RunnerOf(history.Jobs[0]); runner == nil || runner.Name != "mac-a" {
+		t.Errorf("expected job 12 to belong to mac-a, got %+v", runner)
+	}
+}
+
+func TestFetchRunnerJobHistory_SelectsRunnerGroup(t *testing.T) {
+	start := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	jobs := []*entity.Job{
+		{ID: 1, RunnerID: ptrInt64(1), StartedAt: &start},
+		{ID: 2, RunnerID: ptrInt64(2), StartedAt: &start},
+	}
+	runnerRepo := &testhelpers.StubRunnerRepository{
+		Groups: map[string][]*entity.Runner{"gpu": {{ID: 2, Name: "gpu-1"}}},
+	}
+
+	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{Jobs: jobs}, runnerRepo)
+	history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Group: "gpu"}, HistoryOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("FetchRunnerJobHistory error: %v", err)
+	}
+	if len(history.Jobs) != 1 || history.Jobs[0].ID != 2 {
+		t.Fatalf("expected job 2, got %+v", history.Jobs)
+	}
+
+	if _, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Group: "missing"}, HistoryOptions{Limit: 10}); err == nil {
+		t.Error("expected error for unknown runner group")
+	}
+}
+
+func TestFetchRunnerJobHistory_GlobWithoutMatchesFails(t *testing.T) {
+	runnerRepo := &testhelpers.StubRunnerRepository{Runners: []*entity.Runner{{ID: 1, Name: "linux-a"}}}
+	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{}, runnerRepo)
+
+	_, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Names: []string{"mac-*"}}, HistoryOptions{Limit: 10})
+	if err == nil || !strings.Contains(err.Error(), "no runners match 'mac-*'") {
+		t.Fatalf("expected no match error, got %v", err)
+	}
+}
+
+func TestFetchRunnerJobHistory_SelectsRunnersByLabel(t *testing.T) {
+	runners := []*entity.Runner{
+		{ID: 1, Name: "linux-a", Labels: []string{"self-hosted", "linux", "arm64"}},
+		{ID: 2, Name: "linux-b", Labels: []string{"self-hosted", "linux", "x64"}},
+		{ID: 3, Name: "mac-a", Labels: []string{"self-hosted", "macos", "arm64"}},
+	}
+	start := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	jobs := []*entity.Job{
+		{ID: 10, RunnerID: ptrInt64(1), StartedAt: &start},
+		{ID: 11, RunnerID: ptrInt64(2), StartedAt: &start},
+		{ID: 12, RunnerID: ptrInt64(3), StartedAt: &start},
+	}
+	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{Jobs: jobs}, &testhelpers.StubRunnerRepository{Runners: runners})
+
+	tests := []struct {
+		name            string
+		selector        RunnerSelector
+		expectedRunners []string
+	}{
+		{
+			name:            "single label",
+			selector:        RunnerSelector{Labels: []string{"arm64"}},
+			expectedRunners: []string{"linux-a", "mac-a"},
+		},
+		{
+			name:            "labels are combined with AND",
+			selector:        RunnerSelector{Labels: []string{"linux", "arm64"}},
+			expectedRunners: []string{"linux-a"},
+		},
+		{
+			name:            "labels narrow named runners",
+			selector:        RunnerSelector{Names: []string{"linux-*"}, Labels: []string{"x64"}},
+			expectedRunners: []string{"linux-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), tt.selector, HistoryOptions{Limit: 10})
+			if err != nil {
+				t.Fatalf("FetchRunnerJobHistory error: %v", err)
+			}
+
+			var names []string
+			for _, runner := range history.Runners {
+				names = append(names, runner.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expectedRunners, ",") {
+				t.Errorf("expected runners %v, got %v", tt.expectedRunners, names)
+			}
+			if len(history.Jobs) != len(tt.expectedRunners) {
+				t.Errorf("expected %d jobs, got %d", len(tt.expectedRunners), len(history.Jobs))
+			}
+		})
+	}
+
+	_, err := runnerLogger.FetchRunnerJobHistory(context.Background(), RunnerSelector{Labels: []string{"gpu"}}, HistoryOptions{Limit: 10})
+	if err == nil || !strings.Contains(err.Error(), "no runners have labels: gpu") {
+		t.Errorf("expected no runners error, got %v", err)
+	}
+}
+
+func TestFetchRunnerJobHistory_MatchSeveralRunnerNames(t *testing.T) {
+	started := time.Date(2025, 11, 16, 0, 0, 0, 0, time.UTC)
+	jobs := []*entity.Job{
+		{ID: 1, RunnerName: ptrString("ci-a"), StartedAt: &started},
+		{ID: 2, RunnerName: ptrString("ci-b"), StartedAt: &started},
+		{ID: 3, RunnerName: ptrString("ci-c"), StartedAt: &started},
+	}
+
+	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{Jobs: jobs}, &testhelpers.FailingRunnerRepository{Err: errors.New("not found")})
+	selector := RunnerSelector{Names: []string{"ci-a", "ci-c"}, MatchJobsByName: true}
+	history, err := runnerLogger.FetchRunnerJobHistory(context.Background(), selector, HistoryOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("FetchRunnerJobHistory error: %v", err)
+	}
+	if len(history.Jobs) != 2 || history.Jobs[0].ID != 1 || history.Jobs[1].ID != 3 {
+		t.Fatalf("expected jobs 1 and 3, got %+v", history.Jobs)
+	}
+}
+
+func ptrString(v string) *string {
+	return &v
+}
+
 func ptrInt64(v int64) *int64 {
 	return &v
 }
+
+func ptrTime(v time.Time) *time.Time {
+	return &v
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+)
+
+// MannWhitneyU returns the two-sided p-value of the Mann-Whitney U test, which checks whether
+// values from one sample tend to be larger than values from the other
+// It uses the normal approximation with tie and continuity corrections. The second return
+// value is false when either sample has fewer than two values.
+func MannWhitneyU(a, b []float64) (float64, bool) {
+	n1, n2 := len(a), len(b)
+	if n1 < 2 || n2 < 2 {
+		return 0, false
+	}
+
+	type value struct {
+		v       float64
+		fromA   bool
+		ranking float64
+	}
+	values := make([]value, 0, n1+n2)
+	for _, v := range a {
+		values = append(values, value{v: v, fromA: true})
+	}
+	for _, v := range b {
+		values = append(values, value{v: v})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].v < values[j].v })
+
+	// Tied values share the average of their ranks
+	n := float64(n1 + n2)
+	var tieTerm float64
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].v == values[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			values[k].ranking = rank
+		}
+		t := float64(j - i)
+		tieTerm += t*t*t - t
+		i = j
+	}
+
+	var rankSumA float64
+	for _, v := range values {
+		if v.fromA {
+			rankSumA += v.ranking
+		}
+	}
+
+	u := rankSumA - float64(n1*(n1+1))/2
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieTerm/(n*(n-1)))
+	if variance <= 0 {
+		// Every value is the same
+		return 1, true
+	}
+
+	z := math.Max(math.Abs(u-mean)-0.5, 0) / math.Sqrt(variance)
+	return math.Erfc(z / math.Sqrt2), true
+}
+
+// FisherExact returns the two-sided p-value of Fisher's exact test on a 2x2 table,
+// which checks whether the proportion of hits differs between two groups
+// hitsA of totalA and hitsB of totalB are the hits of each group. The second return value is
+// false when either group is empty.
+func FisherExact(hitsA, totalA, hitsB, totalB int) (float64, bool) {
+	if totalA <= 0 || totalB <= 0 {
+		return 0, false
+	}
+
+	hits := hitsA + hitsB
+	observed := hypergeometric(hitsA, totalA, totalB, hits)
+
+	// Sum the probabilities of every table with the same margins that is at most as likely
+	var p float64
+	for k := max(0, hits-totalB); k <= min(hits, totalA); k++ {
+		if prob := hypergeometric(k, totalA, totalB, hits); prob <= observed*(1+1e-7) {
+			p += prob
+		}
+	}
+	return math.Min(p, 1), true
+}
+
+// hypergeometric returns the probability that k of the hits fall in group A
+func hypergeometric(k, totalA, totalB, hits int) float64 {
+	return math.Exp(logChoose(totalA, k) + logChoose(totalB, hits-k) - logChoose(totalA+totalB, hits))
+}
+
+// logChoose returns the natural logarithm of the binomial coefficient n choose k
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// HolmAdjust returns the p-values adjusted with the Holm-Bonferroni method, in the given order
+// Testing many hypotheses at once finds some "significant" differences by chance alone; an
+// adjusted p-value below alpha keeps the chance of any false positive among all the tests
+// below alpha.
+func HolmAdjust(pValues []float64) []float64 {
+	order := make([]int, len(pValues))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return pValues[order[i]] < pValues[order[j]] })
+
+	// The k-th smallest p-value is multiplied by the number of tests left, keeping the order
+	adjusted := make([]float64, len(pValues))
+	var previous float64
+	for rank, i := range order {
+		previous = math.Max(previous, math.Min(1, float64(len(pValues)-rank)*pValues[i]))
+		adjusted[i] = previous
+	}
+	return adjusted
+}
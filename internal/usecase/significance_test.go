@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{name: "separated samples", a: []float64{1, 2, 3, 4, 5}, b: []float64{6, 7, 8, 9, 10}, want: 0.01219},
+		{name: "interleaved samples", a: []float64{1, 3, 5, 7}, b: []float64{2, 4, 6, 8}, want: 0.6650},
+		{name: "identical values", a: []float64{4, 4}, b: []float64{4, 4, 4}, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := MannWhitneyU(tt.a, tt.b)
+			if !ok || math.Abs(p-tt.want) > 1e-4 {
+				t.Errorf("MannWhitneyU() = %v, %v, want %v", p, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := MannWhitneyU([]float64{1}, []float64{2, 3}); ok {
+		t.Error("expected no p-value for a single value")
+	}
+}
+
+func TestFisherExact(t *testing.T) {
+	tests := []struct {
+		name                         string
+		hitsA, totalA, hitsB, totalB int
+		want                         float64
+	}{
+		{name: "lady tasting tea", hitsA: 3, totalA: 4, hitsB: 1, totalB: 4, want: 0.4857},
+		{name: "complete separation", hitsA: 10, totalA: 10, hitsB: 0, totalB: 10, want: 1.0825e-5},
+		{name: "no hits", hitsA: 0, totalA: 5, hitsB: 0, totalB: 7, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := FisherExact(tt.hitsA, tt.totalA, tt.hitsB, tt.totalB)
+			if !ok || math.Abs(p-tt.want) > 1e-4*math.Max(tt.want, 1e-3) {
+				t.Errorf("FisherExact() = %v, %v, want %v", p, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := FisherExact(0, 0, 1, 2); ok {
+		t.Error("expected no p-value for an empty group")
+	}
+}
+
+func TestHolmAdjust(t *testing.T) {
+	got := HolmAdjust([]float64{0.01, 0.04, 0.03, 0.005})
+	want := []float64{0.03, 0.06, 0.06, 0.02}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Fatalf("HolmAdjust() = %v, want %v", got, want)
+		}
+	}
+
+	if got := HolmAdjust([]float64{0.5, 0.9}); got[0] != 1 || got[1] != 1 {
+		t.Errorf("adjusted p-values should be capped at 1, got %v", got)
+	}
+}
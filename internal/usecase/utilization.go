@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+)
+
+// Utilization describes how much of a time window the selected runners spent running jobs
+type Utilization struct {
+	From time.Time
+	To   time.Time
+	// Runners is the number of runners whose time is counted, including runners without jobs
+	Runners int
+	// Busy is the time spent running jobs, summed over runners
+	Busy time.Duration
+	// Days breaks the window down by calendar day
+	Days []DailyUtilization
+	// IdleGaps are the longest gaps between consecutive jobs on the same runner, longest first
+	IdleGaps []IdleGap
+}
+
+// DailyUtilization is the utilization of the runners on a single calendar day
+type DailyUtilization struct {
+	// Date is the start of the day
+	Date time.Time
+	// Busy is the time spent running jobs on this day, summed over runners
+	Busy time.Duration
+	// Available is the part of the day inside the window, multiplied by the number of runners
+	Available time.Duration
+}
+
+// IdleGap is a period between two consecutive jobs on a runner
+type IdleGap struct {
+	Runner string
+	From   time.Time
+	To     time.Time
+}
+
+// Duration returns the length of the gap
+func (g IdleGap) Duration() time.Duration {
+	return g.To.Sub(g.From)
+}
+
+// Available returns the wall-clock time of the window, multiplied by the number of runners
+func (u *Utilization) Available() time.Duration {
+	return u.To.Sub(u.From) * time.Duration(u.Runners)
+}
+
+// Percent returns the busy time as a percentage of the available time
+func (u *Utilization) Percent() float64 {
+	return utilizationPercent(u.Busy, u.Available())
+}
+
+// Percent returns the busy time as a percentage of the available time of the day
+func (d DailyUtilization) Percent() float64 {
+	return utilizationPercent(d.Busy, d.Available)
+}
+
+// RunnerUtilization is the utilization report for the selected runners
+type RunnerUtilization struct {
+	History     *RunnerJobHistory
+	Utilization *Utilization
+}
+
+// FetchRunnerUtilization fetches every job the selected runners picked up since the given time
+// and computes their utilization from since until now, keeping the maxGaps longest idle gaps
+func (r *RunnerLogger) FetchRunnerUtilization(ctx context.Context, selector RunnerSelector, opts HistoryOptions, since time.Time, maxGaps int) (*RunnerUtilization, error) {
+	history, err := r.fetchWindowHistory(ctx, selector, opts, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RunnerUtilization{
+		History:     history,
+		Utilization: ComputeUtilization(history.Jobs, runnerNames(history), since, time.Now(), time.Local, maxGaps),
+	}, nil
+}
+
+// runnerNames returns the names of the selected runners, or of the runners that ran the jobs
+// when they were matched by name pattern
+func runnerNames(history *RunnerJobHistory) []string {
+	var names []string
+	if len(history.Runners) > 0 {
+		for _, runner := range history.Runners {
+			names = append(names, runner.Name)
+		}
+		return names
+	}
+
+	seen := make(map[string]bool)
+	for _, job := range history.Jobs {
+		if job.RunnerName != nil && *job.RunnerName != "" && !seen[*job.RunnerName] {
+			seen[*job.RunnerName] = true
+			names = append(names, *job.RunnerName)
+		}
+	}
+	return names
+}
+
+// interval is a period of time during which a runner was busy
+type interval struct {
+	from time.Time
+	to   time.Time
+}
+
+// ComputeUtilization computes the utilization of the named runners between from and to
+// Jobs still in progress count as busy until to; jobs that have not started are ignored.
+// Days are calendar days in loc.
+func ComputeUtilization(jobs []*entity.Job, runners []string, from, to time.Time, loc *time.Location, maxGaps int) *Utilization {
+	utilization := &Utilization{
+		From:    from,
+		To:      to,
+		Runners: len(runners),
+	}
+
+	busyByRunner := make(map[string][]interval, len(runners))
+	for _, name := range runners {
+		busyByRunner[name] = nil
+	}
+	for _, job := range jobs {
+		if job.RunnerName == nil || job.StartedAt == nil {
+			continue
+		}
+		if _, ok := busyByRunner[*job.RunnerName]; !ok {
+			continue
+		}
+
+		end := to
+		if job.CompletedAt != nil {
+			end = *job.CompletedAt
+		}
+		if busy, ok := clip(interval{from: *job.StartedAt, to: end}, from, to); ok {
+			busyByRunner[*job.RunnerName] = append(busyByRunner[*job.RunnerName], busy)
+		}
+	}
+
+	var allBusy []interval
+	for _, name := range runners {
+		// Jobs can overlap on a runner only in corrupt data, but never count the same time twice
+		busy := mergeIntervals(busyByRunner[name])
+		allBusy = append(allBusy, busy...)
+		for i := 1; i < len(busy); i++ {
+			utilization.IdleGaps = append(utilization.IdleGaps, IdleGap{Runner: name, From: busy[i-1].to, To: busy[i].from})
+		}
+	}
+
+	for _, busy := range allBusy {
+		utilization.Busy += busy.to.Sub(busy.from)
+	}
+
+	for day := startOfDay(from.In(loc)); day.Before(to); day = day.AddDate(0, 0, 1) {
+		dayEnd := day.AddDate(0, 0, 1)
+		window, ok := clip(interval{from: day, to: dayEnd}, from, to)
+		if !ok {
+			continue
+		}
+
+		daily := DailyUtilization{
+			Date:      day,
+			Available: window.to.Sub(window.from) * time.Duration(len(runners)),
+		}
+		for _, busy := range allBusy {
+			if overlap, ok := clip(busy, window.from, window.to); ok {
+				daily.Busy += overlap.to.Sub(overlap.from)
+			}
+		}
+		utilization.Days = append(utilization.Days, daily)
+	}
+
+	sort.SliceStable(utilization.IdleGaps, func(i, j int) bool {
+		return utilization.IdleGaps[i].Duration() > utilization.IdleGaps[j].Duration()
+	})
+	if len(utilization.IdleGaps) > maxGaps {
+		utilization.IdleGaps = utilization.IdleGaps[:maxGaps]
+	}
+
+	return utilization
+}
+
+// clip restricts an interval to the window, reporting whether anything is left
+func clip(i interval, from, to time.Time) (interval, bool) {
+	if i.from.Before(from) {
+		i.from = from
+	}
+	if i.to.After(to) {
+		i.to = to
+	}
+	return i, i.to.After(i.from)
+}
+
+// mergeIntervals sorts intervals and merges the ones that overlap
+func mergeIntervals(intervals []interval) []interval {
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].from.Before(intervals[j].from) })
+
+	var merged []interval
+	for _, i := range intervals {
+		if n := len(merged); n > 0 && !i.from.After(merged[n-1].to) {
+			if i.to.After(merged[n-1].to) {
+				merged[n-1].to = i.to
+			}
+			continue
+		}
+		merged = append(merged, i)
+	}
+	return merged
+}
+
+// startOfDay returns midnight at the start of t's day in t's location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// utilizationPercent returns busy as a percentage of available, or 0 when nothing is available
+func utilizationPercent(busy, available time.Duration) float64 {
+	if available <= 0 {
+		return 0
+	}
+	return float64(busy) / float64(available) * 100
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	testhelpers "github.com/VeyronSakai/gh-runner-log/test"
+)
+
+func TestComputeUtilization(t *testing.T) {
+	from := time.Date(2025, 11, 15, 12, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	job := func(runner string, start time.Time, length time.Duration) *entity.Job {
+		completed := start.Add(length)
+		return &entity.Job{RunnerName: ptrString(runner), Status: entity.StatusCompleted, StartedAt: &start, CompletedAt: &completed}
+	}
+	jobs := []*entity.Job{
+		job("runner-a", from.Add(time.Hour), time.Hour),
+		// Overlaps the previous job on the same runner and must not be counted twice
+		job("runner-a", from.Add(90*time.Minute), time.Hour),
+		job("runner-a", from.Add(10*time.Hour), 30*time.Minute),
+		// Crosses midnight, so it is split across both days
+		job("runner-b", from.Add(11*time.Hour), 2*time.Hour),
+		// Still running at the end of the window
+		{RunnerName: ptrString("runner-b"), Status: entity.StatusInProgress, StartedAt: ptrTime(to.Add(-time.Hour))},
+		// Queued jobs and jobs of other runners are ignored
+		{RunnerName: ptrString("runner-a"), Status: entity.StatusQueued},
+		job("runner-z", from.Add(time.Hour), time.Hour),
+	}
+
+	u := ComputeUtilization(jobs, []string{"runner-a", "runner-b", "runner-c"}, from, to, time.UTC, 2)
+
+	if u.Runners != 3 || u.Available() != 72*time.Hour {
+		t.Errorf("expected 3 runners and 72h available, got %d and %s", u.Runners, u.Available())
+	}
+	expectedBusy := 90*time.Minute + 30*time.Minute + 2*time.Hour + time.Hour
+	if u.Busy != expectedBusy {
+		t.Errorf("expected %s busy, got %s", expectedBusy, u.Busy)
+	}
+	if got, want := u.Percent(), float64(expectedBusy)/float64(72*time.Hour)*100; got != want {
+		t.Errorf("expected %.2f%% utilization, got %.2f%%", want, got)
+	}
+
+	if len(u.Days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(u.Days))
+	}
+	if u.Days[0].Available != 36*time.Hour || u.Days[0].Busy != 90*time.Minute+30*time.Minute+time.Hour {
+		t.Errorf("unexpected first day: %+v", u.Days[0])
+	}
+	if u.Days[1].Available != 36*time.Hour || u.Days[1].Busy != 2*time.Hour {
+		t.Errorf("unexpected second day: %+v", u.Days[1])
+	}
+
+	// runner-a: 2h30m → 10h, runner-b: 13h → 23h; limited to the 2 largest
+	if len(u.IdleGaps) != 2 {
+		t.Fatalf("expected 2 idle gaps, got %d", len(u.IdleGaps))
+	}
+	if u.IdleGaps[0].Runner != "runner-b" || u.IdleGaps[0].Duration() != 10*time.Hour {
+		t.Errorf("unexpected largest gap: %+v", u.IdleGaps[0])
+	}
+	if u.IdleGaps[1].Runner != "runner-a" || u.IdleGaps[1].Duration() != 7*time.Hour+30*time.Minute {
+		t.Errorf("unexpected second gap: %+v", u.IdleGaps[1])
+	}
+}
+
+func TestComputeUtilization_NoRunners(t *testing.T) {
+	now := time.Now()
+	u := ComputeUtilization(nil, nil, now.Add(-time.Hour), now, time.UTC, 5)
+
+	if u.Percent() != 0 || len(u.IdleGaps) != 0 {
+		t.Errorf("unexpected utilization: %+v", u)
+	}
+}
+
+func TestFetchRunnerUtilization_CountsIdleRunners(t *testing.T) {
+	runners := []*entity.Runner{{ID: 1, Name: "linux-a"}, {ID: 2, Name: "linux-b"}}
+	started := time.Now().Add(-time.Hour)
+	completed := started.Add(30 * time.Minute)
+	jobs := []*entity.Job{
+		{ID: 1, RunnerID: ptrInt64(1), RunnerName: ptrString("linux-a"), Status: entity.StatusCompleted, StartedAt: &started, CompletedAt: &completed},
+	}
+
+	runnerLogger := NewRunnerLogger(&testhelpers.StubJobRepository{Jobs: jobs}, &testhelpers.StubRunnerRepository{Runners: runners})
+	report, err := runnerLogger.FetchRunnerUtilization(context.Background(), RunnerSelector{Names: []string{"linux-*"}}, HistoryOptions{}, time.Now().Add(-2*time.Hour), 5)
+	if err != nil {
+		t.Fatalf("FetchRunnerUtilization error: %v", err)
+	}
+
+	if report.Utilization.Runners != 2 || report.Utilization.Busy != 30*time.Minute {
+		t.Errorf("unexpected utilization: %+v", report.Utilization)
+	}
+}
@@ -2,29 +2,63 @@ package testhelpers
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
+	repository "github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
 )
 
 // StubJobRepository implements JobRepository for tests.
 type StubJobRepository struct {
-	Jobs []*entity.Job
-	Err  error
+	Jobs     []*entity.Job
+	Failures []repository.RunFailure
+	Err      error
+	// LastQuery records the query of the most recent call
+	LastQuery repository.JobQuery
+	// Logs maps job IDs to the logs returned by FetchJobLog
+	Logs map[int64]string
+	// LogErr is returned by FetchJobLog when set
+	LogErr error
 }
 
-func (s *StubJobRepository) FetchJobHistory(_ context.Context, runnerID int64) ([]*entity.Job, error) {
+var _ repository.JobRepository = (*StubJobRepository)(nil)
+
+func (s *StubJobRepository) FetchJobHistory(_ context.Context, query repository.JobQuery) (*repository.JobHistoryResult, error) {
+	s.LastQuery = query
 	if s.Err != nil {
 		return nil, s.Err
 	}
+	if query.Strict && len(s.Failures) > 0 {
+		return nil, s.Failures[0].Err
+	}
 
-	// Filter by runner ID if specified
+	// Filter by runner ID or name pattern if specified
 	filtered := make([]*entity.Job, 0)
 	for _, job := range s.Jobs {
-		if runnerID > 0 && !job.IsAssignedToRunner(runnerID) {
+		if !query.Matches(job) {
 			continue
 		}
 		filtered = append(filtered, job)
 	}
 
-	return filtered, nil
+	entity.SortByStartedAtDesc(filtered)
+	if query.Limit > 0 && len(filtered) > query.Limit {
+		filtered = filtered[:query.Limit]
+	}
+
+	return &repository.JobHistoryResult{Jobs: filtered, Failures: s.Failures}, nil
+}
+
+func (s *StubJobRepository) FetchJobLog(_ context.Context, job *entity.Job, maxBytes int) (*repository.JobLogResult, error) {
+	if s.LogErr != nil {
+		return nil, s.LogErr
+	}
+	log, ok := s.Logs[job.ID]
+	if !ok {
+		return nil, fmt.Errorf("no log for job %d", job.ID)
+	}
+	if len(log) > maxBytes {
+		return &repository.JobLogResult{Text: log[len(log)-maxBytes:], Truncated: true}, nil
+	}
+	return &repository.JobLogResult{Text: log}, nil
 }
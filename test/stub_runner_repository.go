@@ -2,21 +2,45 @@ package testhelpers
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/VeyronSakai/gh-runner-log/internal/domain/entity"
 	repository "github.com/VeyronSakai/gh-runner-log/internal/domain/repository"
 )
 
 // StubRunnerRepository implements RunnerRepository for tests.
+// FetchRunnerByName returns Runner when set, otherwise it looks the name up in Runners.
 type StubRunnerRepository struct {
-	Runner *entity.Runner
-	Err    error
+	Runner  *entity.Runner
+	Runners []*entity.Runner
+	Groups  map[string][]*entity.Runner
+	Err     error
 }
 
 var _ repository.RunnerRepository = (*StubRunnerRepository)(nil)
 
-func (s *StubRunnerRepository) FetchRunnerByName(context.Context, string) (*entity.Runner, error) {
-	return s.Runner, s.Err
+func (s *StubRunnerRepository) FetchRunnerByName(_ context.Context, name string) (*entity.Runner, error) {
+	if s.Runner != nil || s.Err != nil {
+		return s.Runner, s.Err
+	}
+	for _, runner := range s.Runners {
+		if runner.Name == name {
+			return runner, nil
+		}
+	}
+	return nil, fmt.Errorf("runner '%s' not found", name)
+}
+
+func (s *StubRunnerRepository) FetchRunners(context.Context) ([]*entity.Runner, error) {
+	return s.Runners, s.Err
+}
+
+func (s *StubRunnerRepository) FetchRunnersInGroup(_ context.Context, group string) ([]*entity.Runner, error) {
+	runners, ok := s.Groups[group]
+	if !ok {
+		return nil, fmt.Errorf("runner group '%s' not found", group)
+	}
+	return runners, s.Err
 }
 
 // FailingRunnerRepository always returns the configured error.
@@ -29,3 +53,11 @@ var _ repository.RunnerRepository = (*FailingRunnerRepository)(nil)
 func (f *FailingRunnerRepository) FetchRunnerByName(context.Context, string) (*entity.Runner, error) {
 	return nil, f.Err
 }
+
+func (f *FailingRunnerRepository) FetchRunners(context.Context) ([]*entity.Runner, error) {
+	return nil, f.Err
+}
+
+func (f *FailingRunnerRepository) FetchRunnersInGroup(context.Context, string) ([]*entity.Runner, error) {
+	return nil, f.Err
+}